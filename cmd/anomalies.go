@@ -0,0 +1,140 @@
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/pkg/errors" //nolint:goimports
+	"sber-test/pkg/anomaly"
+	"sber-test/pkg/formatters"
+	"sber-test/pkg/processors"
+	"sber-test/pkg/trend" //nolint:goimports
+)
+
+// anomalyParams параметры поиска аномалий
+type anomalyParams struct {
+	baseline, current string
+	method            string
+	threshold         float64
+	minHistory        int
+	format            string
+}
+
+func (p *anomalyParams) register(fs *flag.FlagSet) {
+	fs.StringVar(&p.baseline, "baseline", "", "with --current: source file of baseline report, e.g. last week's export")
+	fs.StringVar(&p.current, "current", "", "source file to check against --baseline")
+	fs.StringVar(&p.method, "method", string(anomaly.MethodMAD),
+		"mad (median and median absolute deviation) or zscore (mean and standard deviation)")
+	fs.Float64Var(&p.threshold, "threshold", 0, "max deviation in spreads; default is 3.5 for mad and 3 for zscore")
+	fs.IntVar(&p.minHistory, "min-history", anomaly.DefaultMinHistory,
+		"min number of previous periods (with --dir) or section rows (with --baseline) to estimate spread")
+	fs.StringVar(&p.format, "output", diffFormatTable, "output format: table, csv, json, pretty")
+}
+
+// anomaliesResult --output=json
+type anomaliesResult struct {
+	Method    anomaly.Method    `json:"method"`
+	Threshold float64           `json:"threshold"`
+	Baseline  []string          `json:"baseline"`
+	Current   string            `json:"current"`
+	Anomalies []anomaly.Anomaly `json:"anomalies"`
+}
+
+// runAnomalies sber-test anomalies (--dir exports [--pattern "*.json"] [--date-from auto|name|mtime] [file ..] |
+// --baseline last-week.json --current this-week.json) [--method mad|zscore] [--threshold N] [--min-history N]
+// [--output table|csv|json|pretty] [report params]
+func runAnomalies(args []string) error {
+	const api = "anomalies"
+
+	fs := flag.NewFlagSet(api, flag.ContinueOnError)
+	var params anomalyParams
+	params.register(fs)
+	var period periodParams
+	period.register(fs)
+	registerReportFlags(fs)
+	var reportCache cacheParams
+	reportCache.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	method, err := anomaly.ParseMethod(params.method)
+	if err != nil {
+		return errors.Wrap(err, api)
+	}
+	opts := anomaly.Options{Method: method, Threshold: params.threshold, MinHistory: params.minHistory}
+	if opts.Threshold < 0 {
+		return errors.Errorf("%s: threshold param must be positive", api)
+	}
+	if opts.Threshold == 0 {
+		opts.Threshold = anomaly.DefaultThreshold(method)
+	}
+	if opts.MinHistory < 2 {
+		return errors.Errorf("%s: min-history param must be at least 2", api)
+	}
+	switch params.format {
+	case diffFormatTable, formatters.FormatCSV, diffFormatJSON, diffFormatPretty:
+	default:
+		return errors.Errorf("%s: unknown output format '%s'", api, params.format)
+	}
+	byBaseline := len(params.baseline) > 0 || len(params.current) > 0
+	if byBaseline && (len(params.baseline) == 0 || len(params.current) == 0) {
+		return errors.Errorf("%s: both baseline and current params are required", api)
+	}
+	if byBaseline && (len(period.dir) > 0 || fs.NArg() > 0) {
+		return errors.Errorf("%s: use either baseline and current params or dir param", api)
+	}
+	if err = reportCache.validate(); err != nil {
+		return errors.Wrap(err, api)
+	}
+	subjects, err := reportSubjectsFromArgs()
+	if err != nil {
+		return errors.Wrap(err, api)
+	}
+	if len(subjects) == 0 {
+		return errors.Errorf("%s: asked no any subject to report", api)
+	}
+	if _, err = stagesFromArgs(); err != nil {
+		return errors.Wrap(err, api)
+	}
+	for name := range flagNames(new(anomalyParams).register) {
+		reportCache.ignored[name] = true
+	}
+	result := anomaliesResult{Method: opts.Method, Threshold: opts.Threshold}
+	if byBaseline {
+		result.Baseline, result.Current = []string{params.baseline}, params.current
+		var reports []processors.RecipeProcessorReport
+		reports, err = periodReports(fs, &reportCache, []trend.Period{{Source: params.baseline}, {Source: params.current}})
+		if err != nil {
+			return errors.Wrap(err, api)
+		}
+		result.Anomalies = anomaly.FromBaseline(reports[0], reports[1], opts)
+	} else {
+		periods, err := period.periods(fs.Args())
+		if err != nil {
+			return errors.Wrap(err, api)
+		}
+		if len(periods) < opts.MinHistory+1 {
+			return errors.Errorf("%s: %d periods found, at least %d required (see min-history param)",
+				api, len(periods), opts.MinHistory+1)
+		}
+		reports, err := periodReports(fs, &reportCache, periods)
+		if err != nil {
+			return errors.Wrap(err, api)
+		}
+		for _, p := range periods[:len(periods)-1] {
+			result.Baseline = append(result.Baseline, p.Source)
+		}
+		result.Current = periods[len(periods)-1].Source
+		result.Anomalies = anomaly.FromTrend(trend.Build(periods, reports), opts)
+	}
+	switch params.format {
+	case formatters.FormatCSV:
+		return anomaly.WriteCSV(os.Stdout, result.Anomalies)
+	case diffFormatJSON, diffFormatPretty:
+		if result.Anomalies == nil {
+			result.Anomalies = []anomaly.Anomaly{}
+		}
+		return encodeJSON(params.format == diffFormatPretty, result)
+	}
+	return anomaly.WriteTable(os.Stdout, result.Anomalies)
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors" //nolint:goimports
+	"sber-test/pkg/cache"
+	"sber-test/pkg/processors" //nolint:goimports
+)
+
+// cacheParams параметры кэша отчётов
+type cacheParams struct {
+	noCache     bool
+	dir         string
+	fingerprint string
+
+	// ignored параметры, не влияющие на содержимое отчёта
+	ignored map[string]bool
+}
+
+func (p *cacheParams) register(fs *flag.FlagSet) {
+	p.flags(fs)
+	p.ignored = flagNames(new(cacheParams).flags)
+	for _, register := range []func(*flag.FlagSet){new(outputParams).register, new(watchParams).register} {
+		for name := range flagNames(register) {
+			p.ignored[name] = true
+		}
+	}
+	p.ignored["source"] = true
+}
+
+func (p *cacheParams) flags(fs *flag.FlagSet) {
+	fs.BoolVar(&p.noCache, "no-cache", false, "build report from source even if cached report is valid, and don't cache it")
+	fs.StringVar(&p.dir, "cache-dir", "", "report cache directory; default is sber-test in user cache directory")
+	fs.StringVar(&p.fingerprint, "cache-fingerprint", "hash",
+		"how to detect changed source: hash (SHA-256 of content) or stat (size and modification time)")
+}
+
+// flagNames имена параметров, которые регистрирует register
+func flagNames(register func(*flag.FlagSet)) map[string]bool {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	register(fs)
+	ret := make(map[string]bool)
+	fs.VisitAll(func(f *flag.Flag) {
+		ret[f.Name] = true
+	})
+	return ret
+}
+
+// report отчёт из кэша, если источник, файлы из параметров (files) и параметры fs не менялись;
+// иначе build, результат которого кэшируется. Ошибки кэша не мешают построить отчёт
+func (p *cacheParams) report(fs *flag.FlagSet, source string, files []string,
+	build func() (processors.RecipeProcessorReport, error)) (processors.RecipeProcessorReport, error) {
+	if p.noCache {
+		return build()
+	}
+	c, key, err := p.open(fs, source, files)
+	if err != nil {
+		log.Printf("report cache is disabled: %v", err)
+		return build()
+	}
+	if report, ok := c.Get(key); ok {
+		return report, nil
+	}
+	report, err := build()
+	if err != nil {
+		return report, err
+	}
+	if err = c.Put(key, report); err != nil {
+		log.Printf("report is not cached: %v", err)
+	}
+	return report, nil
+}
+
+func (p *cacheParams) open(fs *flag.FlagSet, source string, files []string) (*cache.Cache, string, error) {
+	mode, err := cache.ParseFingerprintMode(p.fingerprint)
+	if err != nil {
+		return nil, "", err
+	}
+	dir := p.dir
+	if len(dir) == 0 {
+		if dir, err = cache.DefaultDir(); err != nil {
+			return nil, "", err
+		}
+	}
+	fingerprints := make([]string, 0, 1+len(files))
+	for _, f := range append([]string{source}, files...) {
+		if len(f) == 0 {
+			continue
+		}
+		fp, err := cache.FingerprintFile(f, mode)
+		if err != nil {
+			return nil, "", err
+		}
+		fingerprints = append(fingerprints, fp)
+	}
+	params := make(map[string]string)
+	fs.Visit(func(f *flag.Flag) {
+		if v := f.Value.String(); !p.ignored[f.Name] && v != f.DefValue {
+			params[f.Name] = v
+		}
+	})
+	if fs.NArg() > 0 {
+		params[""] = strings.Join(fs.Args(), "\x00")
+	}
+	return cache.New(dir), cache.Key(fingerprints, params), nil
+}
+
+func (p *cacheParams) validate() error {
+	_, err := cache.ParseFingerprintMode(p.fingerprint)
+	return errors.Wrap(err, "'--cache-fingerprint' param has wrong value")
+}
+
+// runCachePrune sber-test cache-prune [--cache-dir dir] [--older-than 720h]
+func runCachePrune(args []string) error {
+	const api = "cache-prune"
+
+	fs := flag.NewFlagSet(api, flag.ContinueOnError)
+	dir := fs.String("cache-dir", "", "report cache directory; default is sber-test in user cache directory")
+	olderThan := fs.Duration("older-than", 30*24*time.Hour, "remove reports not used for this long; 0 removes all reports")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *olderThan < 0 {
+		return errors.Errorf("%s: older-than param has wrong value", api)
+	}
+	if len(*dir) == 0 {
+		var err error
+		if *dir, err = cache.DefaultDir(); err != nil {
+			return errors.Wrap(err, api)
+		}
+	}
+	removed, err := cache.New(*dir).Prune(*olderThan)
+	if err != nil {
+		return errors.Wrap(err, api)
+	}
+	log.Printf("%s: %d cached reports removed from '%s'", api, removed, *dir)
+	return nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+
+	"github.com/pkg/errors" //nolint:goimports
+	"sber-test/pkg/diff"
+	"sber-test/pkg/processors" //nolint:goimports
+)
+
+// diff output formats
+const (
+	diffFormatTable  = "table"
+	diffFormatText   = "text"
+	diffFormatJSON   = "json"
+	diffFormatPretty = "pretty"
+)
+
+// diffResult --output=json
+type diffResult struct {
+	Old     string        `json:"old"`
+	New     string        `json:"new"`
+	Changes []diff.Change `json:"changes"`
+}
+
+// runDiff sber-test diff --old last-week.json --new this-week.json [--output table|text|json|pretty] [report params]
+// [--no-cache] [--cache-dir dir] [--cache-fingerprint hash|stat]
+func runDiff(args []string) error {
+	const api = "diff"
+
+	fs := flag.NewFlagSet(api, flag.ContinueOnError)
+	oldSrc := fs.String("old", "", "source file to compare with, e.g. last week's export")
+	newSrc := fs.String("new", "", "source file to compare")
+	format := fs.String("output", diffFormatTable, "output format: table, text, json, pretty")
+	registerReportFlags(fs)
+	var reportCache cacheParams
+	reportCache.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if len(*oldSrc) == 0 || len(*newSrc) == 0 {
+		return errors.Errorf("%s: both old and new params are required", api)
+	}
+	switch *format {
+	case diffFormatTable, diffFormatText, diffFormatJSON, diffFormatPretty:
+	default:
+		return errors.Errorf("%s: unknown output format '%s'", api, *format)
+	}
+	if err := reportCache.validate(); err != nil {
+		return errors.Wrap(err, api)
+	}
+	subjects, err := reportSubjectsFromArgs()
+	if err != nil {
+		return errors.Wrap(err, api)
+	}
+	if len(subjects) == 0 {
+		return errors.Errorf("%s: asked no any subject to report", api)
+	}
+	if _, err = stagesFromArgs(); err != nil {
+		return errors.Wrap(err, api)
+	}
+	// --old и --new - источники, а не параметры отчёта: отчёты общие с основной командой и trend
+	reportCache.ignored["old"], reportCache.ignored["new"] = true, true
+	reports := make([]processors.RecipeProcessorReport, 0, 2)
+	for _, src := range []string{*oldSrc, *newSrc} {
+		report, err := reportCache.report(fs, src, []string{recipeAliases, regions, catalog},
+			func() (processors.RecipeProcessorReport, error) {
+				return reportFromArgs(src)
+			})
+		if err != nil {
+			return errors.Wrap(err, api)
+		}
+		reports = append(reports, report)
+	}
+	oldReport, newReport := reports[0], reports[1]
+	changes := diff.Reports(oldReport, newReport)
+	switch *format {
+	case diffFormatText:
+		return diff.WriteText(os.Stdout, changes)
+	case diffFormatJSON, diffFormatPretty:
+		if changes == nil {
+			changes = []diff.Change{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		if *format == diffFormatPretty {
+			enc.SetIndent("", "    ")
+		}
+		return enc.Encode(diffResult{Old: *oldSrc, New: *newSrc, Changes: changes})
+	}
+	return diff.WriteTable(os.Stdout, changes)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/pkg/errors" //nolint:goimports
+	"sber-test/internal/fsutil"
+	"sber-test/pkg/generate" //nolint:goimports
+)
+
+// runGenerate sber-test generate [--count N] [--seed N] [--recipes N | --recipe-file recipes.txt] [--recipe-dist uniform|zipf]
+// [--postcodes N] [--postcode-base N] [--postcode-dist uniform|zipf] [--zipf-s S] [--weekdays "Mon=3,.."]
+// [--windows "10AM-3PM=3,.."] [--format json|ndjson|csv] [--out file]
+func runGenerate(args []string) error {
+	const api = "generate"
+
+	fs := flag.NewFlagSet(api, flag.ContinueOnError)
+	count := fs.Int("count", generate.DefaultCount, "number of deliveries")
+	seed := fs.Int64("seed", 0, "random seed to reproduce data; 0 - random seed, printed to stderr")
+	recipes := fs.Int("recipes", generate.DefaultRecipes, "number of distinct recipe names")
+	recipeFile := fs.String("recipe-file", "", "text file with recipe names, one per line, instead of --recipes")
+	recipeDist := fs.String("recipe-dist", string(generate.Uniform), "recipe distribution: uniform or zipf")
+	postcodes := fs.Int("postcodes", generate.DefaultPostcodes, "number of distinct postcodes")
+	postcodeBase := fs.Int("postcode-base", generate.DefaultPostcodeBase, "first postcode")
+	postcodeDist := fs.String("postcode-dist", string(generate.Uniform), "postcode distribution: uniform or zipf")
+	zipfS := fs.Float64("zipf-s", generate.DefaultZipfS, "zipf exponent (> 1): the larger, the more skewed distribution")
+	weekdays := fs.String("weekdays", "", "weekday weights, e.g. \"Mon=3,Tue,Sat=0.5\"; unlisted days are skipped; default is uniform")
+	windows := fs.String("windows", "", "delivery windows with weights, e.g. \"10AM-3PM=3,9AM-5PM\"; default is random windows")
+	format := fs.String("format", string(generate.FormatJSON), "output format: json (array), ndjson or csv")
+	out := fs.String("out", "", "file to write; default is stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *count <= 0 || *recipes <= 0 || *postcodes <= 0 {
+		return errors.Errorf("%s: count, recipes and postcodes params must be positive", api)
+	}
+	// нулевые Options заменяются значениями по умолчанию: недопустимые значения отсекаются здесь
+	if *zipfS <= 1 {
+		return errors.Errorf("%s: zipf-s param must be greater than 1", api)
+	}
+	opts := generate.Options{
+		Count: *count, Seed: *seed, Postcodes: *postcodes, PostcodeBase: postcodeBase, ZipfS: *zipfS,
+		Recipes: generate.RecipeNames(*recipes),
+	}
+	var err error
+	if len(*recipeFile) > 0 {
+		if opts.Recipes, err = generate.LoadRecipeNames(*recipeFile); err != nil {
+			return errors.Wrap(err, api)
+		}
+	}
+	if opts.RecipeDist, err = generate.ParseDistribution(*recipeDist); err != nil {
+		return errors.Wrapf(err, "%s: recipe-dist param has wrong value", api)
+	}
+	if opts.PostcodeDist, err = generate.ParseDistribution(*postcodeDist); err != nil {
+		return errors.Wrapf(err, "%s: postcode-dist param has wrong value", api)
+	}
+	if len(*weekdays) > 0 {
+		if opts.Weekdays, err = generate.ParseWeekdays(*weekdays); err != nil {
+			return errors.Wrapf(err, "%s: weekdays param has wrong value", api)
+		}
+	}
+	if len(*windows) > 0 {
+		if opts.Windows, err = generate.ParseWindows(*windows); err != nil {
+			return errors.Wrapf(err, "%s: windows param has wrong value", api)
+		}
+	}
+	f, err := generate.ParseFormat(*format)
+	if err != nil {
+		return errors.Wrapf(err, "%s: format param has wrong value", api)
+	}
+	if opts.Seed == 0 {
+		opts.Seed = time.Now().UnixNano()
+		log.Printf("%s: seed %d", api, opts.Seed)
+	}
+	g, err := generate.New(opts)
+	if err != nil {
+		return errors.Wrap(err, api)
+	}
+	write := func(w io.Writer) error {
+		_, e := generate.Write(context.Background(), w, f, g)
+		return e
+	}
+	if len(*out) == 0 {
+		err = write(os.Stdout)
+	} else {
+		err = fsutil.WriteFileAtomic(*out, write)
+	}
+	return errors.Wrap(err, api)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/pkg/errors" //nolint:goimports
+	"sber-test/internal"
+	"sber-test/internal/fsutil"
+	"sber-test/pkg/index" //nolint:goimports
+)
+
+// runIndex sber-test index --source file.json [--out file.idx]
+func runIndex(args []string) error {
+	const api = "index"
+
+	fs := flag.NewFlagSet(api, flag.ContinueOnError)
+	src := fs.String("source", "", "points fo source file needs in processing")
+	out := fs.String("out", "", "index file to write; default is source file with '.idx' extension")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if len(*src) == 0 {
+		return errors.Errorf("%s: source param is not provided", api)
+	}
+	if len(*out) == 0 {
+		*out = strings.TrimSuffix(*src, ".json") + ".idx"
+	}
+	if *out == *src {
+		return errors.Errorf("%s: out param points to source file", api)
+	}
+	idx, err := index.Build(context.Background(), internal.NewRecipeDeliveryProviderFromFile(*src))
+	if err != nil {
+		return errors.Wrap(err, api)
+	}
+	err = fsutil.WriteFileAtomic(*out, func(w io.Writer) error {
+		_, e := idx.WriteTo(w)
+		return e
+	})
+	if err != nil {
+		return errors.Wrap(err, api)
+	}
+	log.Printf("%s: %d deliveries, %d recipes, %d postcodes written to '%s'",
+		api, idx.Len(), len(idx.Recipes()), len(idx.Postcodes()), *out)
+	return nil
+}
@@ -2,15 +2,16 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 
+	"github.com/pkg/errors" //nolint:goimports
 	"sber-test/internal"
 	"sber-test/pkg/processors"
-	ts "sber-test/pkg/time-slot"
+	ts "sber-test/pkg/time-slot" //nolint:goimports
 )
 
 var (
@@ -20,33 +21,105 @@ var (
 	reportBusiestPostcode             bool
 	reportMatchedRecipe               string
 	reportDeliveriesByPostcodeAndTime string
+	groupBy                           string
+	groupByCountDistinct              string
+	groupByOrder                      string
+	groupByLimit                      int
+	approximate                       float64
+	normalizeRecipes                  bool
+	recipeAliases                     string
+	postcodeTrim                      bool
+	postcodePad                       int
+	postcodeFormat                    string
+	postcodePrefix                    int
+	regions                           string
+	regionRollup                      string
+	catalog                           string
+	reportCountPerCategory            bool
+	reportCountPerTag                 bool
+	reportTagShare                    string
+	output                            outputParams
+	reportCache                       cacheParams
+	watchSource                       watchParams
 )
 
 func init() {
 	flag.StringVar(&source, "source", "", "points fo source file needs in processing")
-	flag.BoolVar(&reportCountPerRecipe, "count-per-recipe", false, "reports counts per Recipe")
-	flag.BoolVar(&reportUniqueRecipeCount, "unique-recipe-count", false, "reports unique Recipe count")
-	flag.BoolVar(&reportBusiestPostcode, "busiest-postcode", false, "report busiest postcode")
-	flag.StringVar(&reportMatchedRecipe, "find-recipes", "", "report recipes by name(s); example: --find-recipes='Potato,Veggie.Mushroom'")
-	flag.StringVar(&reportDeliveriesByPostcodeAndTime,
+	registerReportFlags(flag.CommandLine)
+	output.register(flag.CommandLine)
+	reportCache.register(flag.CommandLine)
+	watchSource.register(flag.CommandLine)
+}
+
+// registerReportFlags параметры subjects и стадий предобработки, общие для основной команды и diff
+func registerReportFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&reportCountPerRecipe, "count-per-recipe", false, "reports counts per Recipe")
+	fs.BoolVar(&reportUniqueRecipeCount, "unique-recipe-count", false, "reports unique Recipe count")
+	fs.BoolVar(&reportBusiestPostcode, "busiest-postcode", false, "report busiest postcode")
+	fs.StringVar(&reportMatchedRecipe, "find-recipes", "", "report recipes by name(s); example: --find-recipes='Potato,Veggie.Mushroom'")
+	fs.StringVar(&reportDeliveriesByPostcodeAndTime,
 		"deliveries-by-postcode-and-time", "",
 		"deliveries by postcode and time; example: --deliveries-by-postcode-and-time='10120,10AM,3PM'")
+	fs.StringVar(&groupBy, "group-by", "",
+		"count deliveries grouped by dimensions (postcode,recipe,weekday,from,to); example: --group-by='postcode,weekday'")
+	fs.StringVar(&groupByCountDistinct, "count-distinct", "", "with --group-by: count distinct values of dimension per group")
+	fs.StringVar(&groupByOrder, "order-by", "",
+		"with --group-by: order rows by columns (dimension, count, distinct); example: --order-by='count:desc,postcode'")
+	fs.IntVar(&groupByLimit, "limit", 0, "with --group-by: limit number of rows")
+	fs.Float64Var(&approximate, "approximate", 0,
+		"approximate unique recipe count and busiest postcode with given relative error; example: --approximate=0.01")
+	fs.BoolVar(&normalizeRecipes, "normalize-recipes", false, "merge spelling variants of recipe names before reporting")
+	fs.StringVar(&recipeAliases, "recipe-aliases", "",
+		"JSON file mapping canonical recipe names to their variants; implies --normalize-recipes")
+	fs.BoolVar(&postcodeTrim, "postcode-trim", false, "trim spaces around postcodes")
+	fs.IntVar(&postcodePad, "postcode-pad", 0, "left-pad numeric postcodes with zeros to given width")
+	fs.StringVar(&postcodeFormat, "postcode-format", "",
+		"skip and report deliveries with postcode not matching regexp; example: --postcode-format='[0-9]{5}'")
+	fs.IntVar(&postcodePrefix, "postcode-prefix", 0, "roll up all reports by postcode prefix of given length")
+	fs.StringVar(&regions, "regions", "", "CSV or JSON file mapping postcodes to zone, city and depot")
+	fs.StringVar(&regionRollup, "region-rollup", "", "with --regions: roll up all reports by zone, city or depot")
+	fs.StringVar(&catalog, "catalog", "", "CSV or JSON recipe catalog with categories and tags")
+	fs.BoolVar(&reportCountPerCategory, "count-per-category", false, "with --catalog: reports counts per recipe category")
+	fs.BoolVar(&reportCountPerTag, "count-per-tag", false, "with --catalog: reports counts per recipe tag")
+	fs.StringVar(&reportTagShare, "tag-share", "",
+		"with --catalog: reports share of deliveries with tag(s); example: --tag-share='vegetarian,vegan'")
+}
+
+// exitCode ошибка команды, по которой надо завершиться с кодом без сообщения в stderr
+type exitCode int
+
+func (c exitCode) Error() string {
+	return fmt.Sprintf("exit code %d", int(c))
 }
 
 func reportError(formats string, args ...interface{}) {
 	_, _ = fmt.Fprintf(os.Stderr, formats, args...)
 }
 
-func reportSubjectsFromArgs() []processors.RecipeReportSubj {
+func reportSubjectsFromArgs() ([]processors.RecipeReportSubj, error) {
 	var subjects []processors.RecipeReportSubj
 	if reportCountPerRecipe {
 		subjects = append(subjects, processors.ReportCounterPerRecipe())
 	}
+	if approximate < 0 || approximate >= 1 || (approximate > 0 && approximate < processors.MinApproxErrorRate) {
+		return nil, errors.New("'--approximate' param has wrong value")
+	}
+	if approximate > 0 && !reportUniqueRecipeCount && !reportBusiestPostcode {
+		return nil, errors.New("'--approximate' param requires '--unique-recipe-count' or '--busiest-postcode'")
+	}
 	if reportUniqueRecipeCount {
-		subjects = append(subjects, processors.ReportUniqueRecipes())
+		if approximate > 0 {
+			subjects = append(subjects, processors.ReportUniqueRecipesApprox(approximate))
+		} else {
+			subjects = append(subjects, processors.ReportUniqueRecipes())
+		}
 	}
 	if reportBusiestPostcode {
-		subjects = append(subjects, processors.ReportBusiestPostcode())
+		if approximate > 0 {
+			subjects = append(subjects, processors.ReportBusiestPostcodeApprox(approximate, 0))
+		} else {
+			subjects = append(subjects, processors.ReportBusiestPostcode())
+		}
 	}
 	if len(reportMatchedRecipe) > 0 {
 		var names []string
@@ -56,8 +129,7 @@ func reportSubjectsFromArgs() []processors.RecipeReportSubj {
 			}
 		}
 		if len(names) == 0 {
-			reportError("'--find-recipes' param has wrong value")
-			os.Exit(1)
+			return nil, errors.New("'--find-recipes' param has wrong value")
 		}
 		subjects = append(subjects, processors.ReportIfMatchedRecipes(names[0], names[1:]...))
 	}
@@ -65,8 +137,7 @@ func reportSubjectsFromArgs() []processors.RecipeReportSubj {
 		raw := strings.Split(reportDeliveriesByPostcodeAndTime, ",")
 		const p = "--deliveries-by-postcode-and-time"
 		if len(raw) != 3 {
-			reportError("'%s' param has wrong value", p)
-			os.Exit(1)
+			return nil, errors.Errorf("'%s' param has wrong value", p)
 		}
 		var from, to ts.Hour
 		err := from.FromString([]byte(raw[1]))
@@ -74,37 +145,254 @@ func reportSubjectsFromArgs() []processors.RecipeReportSubj {
 			err = to.FromString([]byte(raw[2]))
 		}
 		if err != nil {
-			reportError("'%s' param has wrong value cause %v", p, err)
-			os.Exit(1)
+			return nil, errors.Wrapf(err, "'%s' param has wrong value", p)
 		}
 		subjects = append(subjects, processors.ReportDeliveryCountForPostcodeAndTime(raw[0], from, to))
 	}
-	return subjects
+	if (reportCountPerCategory || reportCountPerTag || len(reportTagShare) > 0) && len(catalog) == 0 {
+		return nil, errors.New("'--count-per-category', '--count-per-tag' and '--tag-share' params require '--catalog'")
+	}
+	if reportCountPerCategory {
+		subjects = append(subjects, processors.ReportCountPerCategory())
+	}
+	if reportCountPerTag {
+		subjects = append(subjects, processors.ReportCountPerTag())
+	}
+	if len(reportTagShare) > 0 {
+		var tags []string
+		for _, tag := range strings.Split(reportTagShare, ",") {
+			if tag = strings.TrimSpace(tag); len(tag) > 0 {
+				tags = append(tags, tag)
+			}
+		}
+		if len(tags) == 0 {
+			return nil, errors.New("'--tag-share' param has wrong value")
+		}
+		subjects = append(subjects, processors.ReportTagShare(tags[0], tags[1:]...))
+	}
+	if len(groupBy) > 0 {
+		subj, err := groupBySubjectFromArgs()
+		if err != nil {
+			return nil, errors.Wrap(err, "'--group-by' params have wrong value")
+		}
+		subjects = append(subjects, subj)
+	}
+	return subjects, nil
+}
+
+func groupBySubjectFromArgs() (processors.RecipeReportSubj, error) {
+	var spec processors.GroupBySpec
+	for _, s := range strings.Split(groupBy, ",") {
+		d, err := processors.ParseDimension(s)
+		if err != nil {
+			return nil, err
+		}
+		spec.By = append(spec.By, d)
+	}
+	if len(groupByCountDistinct) > 0 {
+		d, err := processors.ParseDimension(groupByCountDistinct)
+		if err != nil {
+			return nil, err
+		}
+		spec.CountDistinct = d
+	}
+	if len(groupByOrder) > 0 {
+		for _, s := range strings.Split(groupByOrder, ",") {
+			o, err := processors.ParseGroupOrder(s)
+			if err != nil {
+				return nil, err
+			}
+			spec.OrderBy = append(spec.OrderBy, o)
+		}
+	}
+	spec.Limit = groupByLimit
+	if spec.NeedsRegions() && len(regions) == 0 {
+		return nil, errors.New("zone, city and depot dimensions require '--regions'")
+	}
+	return processors.ReportGroupBy(spec)
+}
+
+func stagesFromArgs() ([]processors.RecipeDeliveryStage, error) {
+	var stages []processors.RecipeDeliveryStage
+	if normalizeRecipes || len(recipeAliases) > 0 {
+		var aliases map[string]string
+		if len(recipeAliases) > 0 {
+			var err error
+			if aliases, err = internal.LoadRecipeAliases(recipeAliases); err != nil {
+				return nil, errors.Wrap(err, "'--recipe-aliases' param has wrong value")
+			}
+		}
+		stages = append(stages, processors.NormalizeRecipes(aliases))
+	}
+	var rules []processors.PostcodeRule
+	if postcodeTrim {
+		rules = append(rules, processors.PostcodeTrim())
+	}
+	if postcodePad > 0 {
+		rules = append(rules, processors.PostcodeZeroPad(postcodePad))
+	}
+	if len(postcodeFormat) > 0 {
+		re, err := regexp.Compile(postcodeFormat)
+		if err != nil {
+			return nil, errors.Wrap(err, "'--postcode-format' param has wrong value")
+		}
+		rules = append(rules, processors.PostcodeFormat(re))
+	}
+	if len(rules) > 0 {
+		stages = append(stages, processors.ValidatePostcodes(rules[0], rules[1:]...))
+	}
+	if len(regions) > 0 {
+		table, err := internal.LoadRegions(regions)
+		if err != nil {
+			return nil, errors.Wrap(err, "'--regions' param has wrong value")
+		}
+		rollup := processors.DimNone
+		if len(regionRollup) > 0 {
+			if rollup, err = processors.ParseDimension(regionRollup); err != nil {
+				return nil, errors.Wrap(err, "'--region-rollup' param has wrong value")
+			}
+		}
+		var stage processors.RecipeDeliveryStage
+		if stage, err = processors.EnrichRegions(table, rollup); err != nil {
+			return nil, errors.Wrap(err, "'--region-rollup' param has wrong value")
+		}
+		stages = append(stages, stage)
+	} else if len(regionRollup) > 0 {
+		return nil, errors.New("'--region-rollup' param requires '--regions'")
+	}
+	if len(catalog) > 0 {
+		recipes, err := internal.LoadCatalog(catalog)
+		if err != nil {
+			return nil, errors.Wrap(err, "'--catalog' param has wrong value")
+		}
+		var stage processors.RecipeDeliveryStage
+		if stage, err = processors.JoinCatalog(recipes); err != nil {
+			return nil, errors.Wrap(err, "'--catalog' param has wrong value")
+		}
+		stages = append(stages, stage)
+	}
+	if postcodePrefix < 0 {
+		return nil, errors.New("'--postcode-prefix' param has wrong value")
+	}
+	if postcodePrefix > 0 && len(regionRollup) > 0 {
+		return nil, errors.New("'--postcode-prefix' and '--region-rollup' params are mutually exclusive")
+	}
+	if postcodePrefix > 0 {
+		stages = append(stages, processors.RollupPostcodes(postcodePrefix))
+	}
+	return stages, nil
+}
+
+// commands subcommands: sber-test <command> [args]
+var commands = map[string]func(args []string) error{
+	"anomalies":   runAnomalies,
+	"cache-prune": runCachePrune,
+	"diff":        runDiff,
+	"generate":    runGenerate,
+	"index":       runIndex,
+	"query":       runQuery,
+	"schema":      runSchema,
+	"serve":       runServe,
+	"trend":       runTrend,
+	"validate":    runValidate,
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		if cmd, ok := commands[os.Args[1]]; ok {
+			if err := cmd(os.Args[2:]); err != nil {
+				var code exitCode
+				if errors.As(err, &code) {
+					os.Exit(int(code))
+				}
+				reportError("%v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
 	flag.Parse()
 	if len(source) == 0 {
 		reportError("source param is not provided")
 		os.Exit(1)
 	}
-	subjects := reportSubjectsFromArgs()
+	if err := output.validate(); err != nil {
+		reportError("output params have wrong value cause %v", err)
+		os.Exit(1)
+	}
+	if err := reportCache.validate(); err != nil {
+		reportError("%v", err)
+		os.Exit(1)
+	}
+	subjects, err := reportSubjectsFromArgs()
+	if err != nil {
+		reportError("%v", err)
+		os.Exit(1)
+	}
 	if len(subjects) == 0 {
 		reportError("asked no any subject to report")
 		os.Exit(1)
 	}
-	reporter := processors.NewRecipeReportProcessor(subjects[0], subjects[1:]...)
-	src := internal.NewRecipeDeliveryProviderFromFile(source)
-	ctx := context.Background()
-	report, err := reporter.Process(ctx, src)
+	if _, err = stagesFromArgs(); err != nil {
+		reportError("%v", err)
+		os.Exit(1)
+	}
+	report, err := buildReport()
 	if err != nil {
 		reportError("%v", err)
 		os.Exit(1)
 	}
-	var decodedResult []byte
-	if decodedResult, err = json.Marshal(report); err != nil {
+	if err = printReport(report); err != nil {
 		reportError("%v", err)
 		os.Exit(1)
 	}
-	_, _ = fmt.Fprintf(os.Stdout, "%s\n", string(decodedResult))
+	if watchSource.enabled {
+		files := []string{source}
+		for _, f := range []string{recipeAliases, regions, catalog} {
+			if len(f) > 0 {
+				files = append(files, f)
+			}
+		}
+		if err = watchSource.run(files, report, buildReport, printReport); err != nil {
+			reportError("%v", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// buildReport отчёт по --source (или из кэша)
+func buildReport() (processors.RecipeProcessorReport, error) {
+	return reportCache.report(flag.CommandLine, source, []string{recipeAliases, regions, catalog},
+		func() (processors.RecipeProcessorReport, error) {
+			return reportFromArgs(source)
+		})
+}
+
+// reportFromArgs отчёт по файлу src с subjects и стадиями из параметров командной строки; subjects
+// и стадии создаются заново при каждом вызове, файлы --recipe-aliases, --regions и --catalog перечитываются
+func reportFromArgs(src string) (processors.RecipeProcessorReport, error) {
+	subjects, err := reportSubjectsFromArgs()
+	if err != nil {
+		return processors.RecipeProcessorReport{}, err
+	}
+	if len(subjects) == 0 {
+		return processors.RecipeProcessorReport{}, errors.New("asked no any subject to report")
+	}
+	stages, err := stagesFromArgs()
+	if err != nil {
+		return processors.RecipeProcessorReport{}, err
+	}
+	reporter := processors.NewRecipeReportProcessor(subjects[0], subjects[1:]...).WithStages(stages...)
+	provider, err := internal.OpenSource(src)
+	if err != nil {
+		return processors.RecipeProcessorReport{}, err
+	}
+	return reporter.Process(context.Background(), provider)
+}
+
+func printReport(report processors.RecipeProcessorReport) error {
+	if err := output.describe(flag.CommandLine, source); err != nil {
+		return err
+	}
+	return output.print(report)
 }
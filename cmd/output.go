@@ -0,0 +1,120 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors" //nolint:goimports
+	"sber-test/internal"
+	"sber-test/internal/fsutil"
+	"sber-test/pkg/formatters" //nolint:goimports
+	"sber-test/pkg/processors"
+)
+
+// outputParams параметры вывода отчёта, общие для всех команд
+type outputParams struct {
+	format    string
+	section   string
+	outDir    string
+	csvBOM    bool
+	tmpl      string
+	textfile  string
+	envelope  bool
+	omitEmpty bool
+
+	// meta сведения о запуске для --envelope; заполняются командой перед print
+	meta formatters.EnvelopeMeta
+
+	f formatters.ReportFormatter
+}
+
+func (p *outputParams) register(fs *flag.FlagSet) {
+	fs.StringVar(&p.format, "output", formatters.FormatJSON,
+		"output format: "+strings.Join(formatters.Names(), ", "))
+	fs.StringVar(&p.section, "section", "", "with --output=csv: report section to export; example: --section=count_per_recipe")
+	fs.StringVar(&p.outDir, "out-dir", "", "with --output=csv: write every report section to <out-dir>/<section>.csv")
+	fs.BoolVar(&p.csvBOM, "csv-bom", false, "with --output=csv: start files with UTF-8 BOM for Excel")
+	fs.StringVar(&p.tmpl, "template", "", "render report with text/template file instead of --output")
+	fs.StringVar(&p.textfile, "textfile", "",
+		"with --output=openmetrics: atomically write metrics to file for node_exporter textfile collector")
+	fs.BoolVar(&p.omitEmpty, "omit-empty", false,
+		"omit zero and empty report sections; by default every requested section is printed")
+	fs.BoolVar(&p.envelope, "envelope", false,
+		"with --output=json, pretty, yaml or toml: wrap report with schema version, generation time, source and params")
+}
+
+func (p *outputParams) formatter() (formatters.ReportFormatter, error) {
+	if p.f != nil {
+		return p.f, nil
+	}
+	csvOnly := len(p.section) > 0 || len(p.outDir) > 0 || p.csvBOM
+	var err error
+	switch {
+	case len(p.tmpl) > 0:
+		if p.format != formatters.FormatJSON || csvOnly || len(p.textfile) > 0 || p.envelope {
+			return nil, errors.New("'--template' param can't be combined with other output params")
+		}
+		p.f, err = formatters.LoadTemplateFormatter(p.tmpl)
+	case len(p.textfile) > 0 && p.format != formatters.FormatOpenMetrics:
+		return nil, errors.New("'--textfile' param requires '--output=openmetrics'")
+	case p.envelope:
+		if csvOnly {
+			return nil, errors.New("'--envelope' param can't be combined with csv params")
+		}
+		p.f, err = formatters.NewEnvelopeFormatter(p.format)
+	case p.format == formatters.FormatCSV:
+		p.f = formatters.CSVFormatter{Section: p.section, BOM: p.csvBOM}
+	case csvOnly:
+		return nil, errors.New("'--section', '--out-dir' and '--csv-bom' params require '--output=csv'")
+	default:
+		p.f, err = formatters.Get(p.format)
+	}
+	return p.f, err
+}
+
+// describe заполняет meta для --envelope: параметры, установленные в fs, позиционные аргументы и источник
+func (p *outputParams) describe(fs *flag.FlagSet, source string) error {
+	if !p.envelope {
+		return nil
+	}
+	p.meta.Params = make(map[string]string)
+	fs.Visit(func(f *flag.Flag) {
+		p.meta.Params[f.Name] = f.Value.String()
+	})
+	p.meta.Args = fs.Args()
+	var err error
+	p.meta.Source, err = internal.DescribeSource(source)
+	return err
+}
+
+func (p *outputParams) validate() error {
+	_, err := p.formatter()
+	return err
+}
+
+func (p *outputParams) print(report processors.RecipeProcessorReport) error {
+	f, err := p.formatter()
+	if err != nil {
+		return err
+	}
+	if p.omitEmpty {
+		report = report.OmitEmpty()
+	}
+	if csvf, ok := f.(formatters.CSVFormatter); ok && len(p.outDir) > 0 {
+		_, err = csvf.WriteFiles(p.outDir, report)
+		return err
+	}
+	if ef, ok := f.(formatters.EnvelopeFormatter); ok {
+		ef.Meta = p.meta
+		f = ef
+	}
+	if len(p.textfile) > 0 {
+		return fsutil.WriteFileAtomic(p.textfile, func(w io.Writer) error {
+			// textfile collector читает текстовый формат Prometheus, а не OpenMetrics
+			return formatters.WritePrometheusText(w, report)
+		})
+	}
+	return f.Format(os.Stdout, report)
+}
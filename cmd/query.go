@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/pkg/errors" //nolint:goimports
+	"sber-test/internal"
+	"sber-test/pkg/processors"
+	"sber-test/pkg/query" //nolint:goimports
+)
+
+// runQuery sber-test query --source file.json [--regions regions.csv] [--output format] [--no-cache] "SELECT ..." ["SELECT ..." ..]
+func runQuery(args []string) error {
+	const api = "query"
+
+	fs := flag.NewFlagSet(api, flag.ContinueOnError)
+	src := fs.String("source", "", "points fo source file needs in processing")
+	regionsFile := fs.String("regions", "", "CSV or JSON file mapping postcodes to zone, city and depot")
+	var output outputParams
+	output.register(fs)
+	var reportCache cacheParams
+	reportCache.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if len(*src) == 0 {
+		return errors.Errorf("%s: source param is not provided", api)
+	}
+	if err := output.validate(); err != nil {
+		return errors.Wrap(err, api)
+	}
+	if err := reportCache.validate(); err != nil {
+		return errors.Wrap(err, api)
+	}
+	if fs.NArg() == 0 {
+		return errors.Errorf("%s: no query provided", api)
+	}
+	subjects := make([]processors.RecipeReportSubj, 0, fs.NArg())
+	for _, s := range fs.Args() {
+		q, err := query.Compile(s)
+		if err != nil {
+			return errors.Wrap(err, api)
+		}
+		if q.NeedsRegions() && len(*regionsFile) == 0 {
+			return errors.Errorf("%s: zone, city and depot dimensions require regions param", api)
+		}
+		var subj processors.RecipeReportSubj
+		if subj, err = q.Subject(); err != nil {
+			return errors.Wrap(err, api)
+		}
+		subjects = append(subjects, subj)
+	}
+	reporter := processors.NewRecipeReportProcessor(subjects[0], subjects[1:]...)
+	if len(*regionsFile) > 0 {
+		table, err := internal.LoadRegions(*regionsFile)
+		if err != nil {
+			return errors.Wrap(err, api)
+		}
+		var stage processors.RecipeDeliveryStage
+		if stage, err = processors.EnrichRegions(table, processors.DimNone); err != nil {
+			return errors.Wrap(err, api)
+		}
+		reporter.WithStages(stage)
+	}
+	report, err := reportCache.report(fs, *src, []string{*regionsFile}, func() (processors.RecipeProcessorReport, error) {
+		provider, err := internal.OpenSource(*src)
+		if err != nil {
+			return processors.RecipeProcessorReport{}, errors.Wrap(err, api)
+		}
+		return reporter.Process(context.Background(), provider)
+	})
+	if err != nil {
+		return err
+	}
+	if err = output.describe(fs, *src); err != nil {
+		return errors.Wrap(err, api)
+	}
+	return output.print(report)
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+
+	"sber-test/pkg/formatters"
+)
+
+// runSchema sber-test schema [--bare]
+func runSchema(args []string) error {
+	const api = "schema"
+
+	fs := flag.NewFlagSet(api, flag.ContinueOnError)
+	bare := fs.Bool("bare", false, "schema of report without --envelope")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	schema := formatters.EnvelopeSchema()
+	if *bare {
+		schema = formatters.ReportSchema()
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "    ")
+	return enc.Encode(schema)
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors" //nolint:goimports
+	"sber-test/internal"
+	"sber-test/pkg/index"
+	"sber-test/pkg/processors"
+	"sber-test/pkg/providers"
+	"sber-test/pkg/rpc"
+	"sber-test/pkg/server" //nolint:goimports
+)
+
+// runServe sber-test serve --source file.json [--addr :8080] [--grpc-addr :9090] [--regions regions.csv] [--catalog catalog.csv]
+// [--preload] [--shutdown-timeout 10s]
+func runServe(args []string) error {
+	const api = "serve"
+
+	fs := flag.NewFlagSet(api, flag.ContinueOnError)
+	src := fs.String("source", "", "points fo source file needs in processing")
+	addr := fs.String("addr", ":8080", "address to listen on")
+	grpcAddr := fs.String("grpc-addr", "", "address to serve gRPC ReportService on; not served when empty")
+	regions := fs.String("regions", "", "with --grpc-addr: CSV or JSON file mapping postcodes to zone, city and depot")
+	catalog := fs.String("catalog", "", "with --grpc-addr: CSV or JSON recipe catalog with categories and tags")
+	preload := fs.Bool("preload", false, "load source into memory once instead of reading it on every request")
+	shutdownTimeout := fs.Duration("shutdown-timeout", 10*time.Second, "time to wait for in-flight requests on shutdown")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if len(*src) == 0 {
+		return errors.Errorf("%s: source param is not provided", api)
+	}
+	if (len(*regions) > 0 || len(*catalog) > 0) && len(*grpcAddr) == 0 {
+		return errors.Errorf("%s: regions and catalog params require grpc-addr", api)
+	}
+	stages, err := serveStages(*regions, *catalog)
+	if err != nil {
+		return errors.Wrap(err, api)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	provider, err := internal.OpenSource(*src)
+	if err != nil {
+		return errors.Wrap(err, api)
+	}
+	if _, ok := provider.(*index.Index); *preload && !ok {
+		deliveries, err := providers.Collect(ctx, provider)
+		if err != nil {
+			return errors.Wrap(err, api)
+		}
+		provider = deliveries
+		log.Printf("%s: loaded %d deliveries from '%s'", api, len(deliveries), *src)
+	}
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return errors.Wrap(err, api)
+	}
+	log.Printf("%s: listening on %s", api, ln.Addr())
+	served := make(chan error, 2)
+	running := 1
+	go func() {
+		served <- server.Serve(ctx, ln, server.NewHandler(provider, nil), *shutdownTimeout)
+	}()
+	if len(*grpcAddr) > 0 {
+		var grpcLn net.Listener
+		if grpcLn, err = net.Listen("tcp", *grpcAddr); err != nil {
+			stop()
+			<-served
+			return errors.Wrap(err, api)
+		}
+		log.Printf("%s: gRPC listening on %s", api, grpcLn.Addr())
+		running++
+		go func() {
+			served <- rpc.Serve(ctx, grpcLn, rpc.NewServer(provider, stages), *shutdownTimeout)
+		}()
+	}
+	for ; running > 0; running-- {
+		if e := <-served; e != nil {
+			stop() // второй сервер тоже останавливаем
+			if err == nil {
+				err = e
+			}
+		}
+	}
+	if err != nil {
+		return errors.Wrap(err, api)
+	}
+	log.Printf("%s: stopped", api)
+	return nil
+}
+
+// serveStages стадии --regions и --catalog для gRPC: файлы читаются один раз, а стадии накапливают
+// состояние и создаются на каждый запрос; nil, если файлы не заданы
+func serveStages(regions, catalog string) (func() []processors.RecipeDeliveryStage, error) {
+	var factories []func() (processors.RecipeDeliveryStage, error)
+	if len(regions) > 0 {
+		table, err := internal.LoadRegions(regions)
+		if err != nil {
+			return nil, errors.Wrap(err, "'--regions' param has wrong value")
+		}
+		factories = append(factories, func() (processors.RecipeDeliveryStage, error) {
+			return processors.EnrichRegions(table, processors.DimNone)
+		})
+	}
+	if len(catalog) > 0 {
+		recipes, err := internal.LoadCatalog(catalog)
+		if err != nil {
+			return nil, errors.Wrap(err, "'--catalog' param has wrong value")
+		}
+		factory := func() (processors.RecipeDeliveryStage, error) {
+			return processors.JoinCatalog(recipes)
+		}
+		if _, err = factory(); err != nil {
+			return nil, errors.Wrap(err, "'--catalog' param has wrong value")
+		}
+		factories = append(factories, factory)
+	}
+	if len(factories) == 0 {
+		return nil, nil
+	}
+	return func() []processors.RecipeDeliveryStage {
+		stages := make([]processors.RecipeDeliveryStage, 0, len(factories))
+		for _, factory := range factories {
+			// ошибки проверены при запуске
+			stage, _ := factory()
+			stages = append(stages, stage)
+		}
+		return stages
+	}, nil
+}
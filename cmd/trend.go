@@ -0,0 +1,167 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors" //nolint:goimports
+	"sber-test/pkg/formatters"
+	"sber-test/pkg/processors"
+	"sber-test/pkg/trend" //nolint:goimports
+)
+
+// trend date sources
+const (
+	trendDateAuto  = "auto"
+	trendDateName  = "name"
+	trendDateMtime = "mtime"
+)
+
+// periodParams выгрузки за периоды: файлы из --dir и перечисленные в аргументах
+type periodParams struct {
+	dir, pattern, dateFrom string
+}
+
+func (p *periodParams) register(fs *flag.FlagSet) {
+	fs.StringVar(&p.dir, "dir", "", "directory with dated exports")
+	fs.StringVar(&p.pattern, "pattern", "*.json", "with --dir: file name pattern")
+	fs.StringVar(&p.dateFrom, "date-from", trendDateAuto,
+		"period date: name (YYYY-MM-DD or YYYYMMDD in file name), mtime (file modification date) or auto (name, else mtime)")
+}
+
+// periods периоды в порядке дат
+func (p *periodParams) periods(files []string) ([]trend.Period, error) {
+	if len(p.dir) > 0 {
+		matched, err := filepath.Glob(filepath.Join(p.dir, p.pattern))
+		if err != nil {
+			return nil, errors.Wrap(err, "pattern param has wrong value")
+		}
+		files = append(files, matched...)
+	}
+	if len(files) == 0 {
+		return nil, errors.New("no files to report; use dir param or list files")
+	}
+	return trendPeriods(files, p.dateFrom)
+}
+
+// periodReports отчёты по периодам с параметрами из fs (или из кэша)
+func periodReports(fs *flag.FlagSet, reportCache *cacheParams, periods []trend.Period) ([]processors.RecipeProcessorReport, error) {
+	for name := range flagNames(new(periodParams).register) {
+		reportCache.ignored[name] = true
+	}
+	reports := make([]processors.RecipeProcessorReport, 0, len(periods))
+	for _, p := range periods {
+		src := p.Source
+		report, err := reportCache.report(fs, src, []string{recipeAliases, regions, catalog},
+			func() (processors.RecipeProcessorReport, error) {
+				return reportFromArgs(src)
+			})
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// runTrend sber-test trend --dir exports [--pattern "*.json"] [--date-from auto|name|mtime]
+// [--output table|csv|json|pretty] [report params] [file ..]
+func runTrend(args []string) error {
+	const api = "trend"
+
+	fs := flag.NewFlagSet(api, flag.ContinueOnError)
+	var period periodParams
+	period.register(fs)
+	format := fs.String("output", diffFormatTable, "output format: table, csv, json, pretty")
+	registerReportFlags(fs)
+	var reportCache cacheParams
+	reportCache.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	switch *format {
+	case diffFormatTable, formatters.FormatCSV, diffFormatJSON, diffFormatPretty:
+	default:
+		return errors.Errorf("%s: unknown output format '%s'", api, *format)
+	}
+	if err := reportCache.validate(); err != nil {
+		return errors.Wrap(err, api)
+	}
+	subjects, err := reportSubjectsFromArgs()
+	if err != nil {
+		return errors.Wrap(err, api)
+	}
+	if len(subjects) == 0 {
+		return errors.Errorf("%s: asked no any subject to report", api)
+	}
+	if _, err = stagesFromArgs(); err != nil {
+		return errors.Wrap(err, api)
+	}
+	periods, err := period.periods(fs.Args())
+	if err != nil {
+		return errors.Wrap(err, api)
+	}
+	reports, err := periodReports(fs, &reportCache, periods)
+	if err != nil {
+		return errors.Wrap(err, api)
+	}
+	t := trend.Build(periods, reports)
+	switch *format {
+	case formatters.FormatCSV:
+		return trend.WriteCSV(os.Stdout, t)
+	case diffFormatJSON, diffFormatPretty:
+		return encodeJSON(*format == diffFormatPretty, t)
+	}
+	return trend.WriteTable(os.Stdout, t)
+}
+
+// encodeJSON v в stdout, с отступами, если pretty
+func encodeJSON(pretty bool, v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	if pretty {
+		enc.SetIndent("", "    ")
+	}
+	return enc.Encode(v)
+}
+
+// trendPeriods периоды файлов в порядке дат
+func trendPeriods(files []string, dateFrom string) ([]trend.Period, error) {
+	periods := make([]trend.Period, 0, len(files))
+	for _, f := range files {
+		p := trend.Period{Source: f}
+		var ok bool
+		switch dateFrom {
+		case trendDateAuto, trendDateName:
+			p.Date, ok = trend.DateFromName(filepath.Base(f))
+		case trendDateMtime:
+		default:
+			return nil, errors.Errorf("unknown date-from '%s'", dateFrom)
+		}
+		if !ok && dateFrom == trendDateName {
+			return nil, errors.Errorf("no date in file name '%s'", f)
+		}
+		if !ok {
+			info, err := os.Stat(f)
+			if err != nil {
+				return nil, err
+			}
+			y, m, d := info.ModTime().Date()
+			p.Date = time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+		}
+		periods = append(periods, p)
+	}
+	sort.SliceStable(periods, func(i, j int) bool {
+		return periods[i].Date.Before(periods[j].Date)
+	})
+	for i := 1; i < len(periods); i++ {
+		if periods[i].Date.Equal(periods[i-1].Date) {
+			return nil, errors.Errorf("files '%s' and '%s' have the same date %s",
+				periods[i-1].Source, periods[i].Source, periods[i].Date.Format(trend.DateLayout))
+		}
+	}
+	return periods, nil
+}
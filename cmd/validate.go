@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors" //nolint:goimports
+	"sber-test/pkg/index"
+	"sber-test/pkg/validate" //nolint:goimports
+)
+
+// validate output formats
+const (
+	validateFormatJSON   = "json"
+	validateFormatNDJSON = "ndjson"
+	validateFormatText   = "text"
+)
+
+// validate exit codes; 1 - проверку не удалось выполнить
+const (
+	exitWarnings exitCode = 2
+	exitErrors   exitCode = 3
+)
+
+type (
+	// validateFile --output=json: итоги и проблемы файла
+	validateFile struct {
+		Source string `json:"source"`
+		validate.Summary
+		Issues []validate.Issue `json:"issues"`
+		// Omitted проблемы сверх --max-issues
+		Omitted int `json:"omitted_issues,omitempty"`
+	}
+
+	// validateIssue --output=ndjson
+	validateIssue struct {
+		Source string `json:"source"`
+		validate.Issue
+	}
+)
+
+// runValidate sber-test validate [--hours 6AM-10PM] [--output json|ndjson|text] [--max-issues N] file [file ..]
+func runValidate(args []string) error {
+	const api = "validate"
+
+	fs := flag.NewFlagSet(api, flag.ContinueOnError)
+	hours := fs.String("hours", "", "delivery hours policy, e.g. \"6AM-10PM\": warn about delivery windows out of it")
+	format := fs.String("output", validateFormatJSON, "output format: json, ndjson (issue per line) or text")
+	maxIssues := fs.Int("max-issues", 1000, "max issues to output per file, 0 - all; issues are counted anyway")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return errors.Errorf("%s: no files to validate", api)
+	}
+	switch *format {
+	case validateFormatJSON, validateFormatNDJSON, validateFormatText:
+	default:
+		return errors.Errorf("%s: unknown output format '%s'", api, *format)
+	}
+	if *maxIssues < 0 {
+		return errors.Errorf("%s: max-issues param must not be negative", api)
+	}
+	var opts validate.Options
+	if len(*hours) > 0 {
+		h, err := validate.ParseHours(*hours)
+		if err != nil {
+			return errors.Wrapf(err, "%s: hours param has wrong value", api)
+		}
+		opts.Hours = &h
+	}
+	out := bufio.NewWriter(os.Stdout)
+	enc := json.NewEncoder(out)
+	var results []validateFile
+	var worst validate.Severity
+	for _, src := range fs.Args() {
+		res := validateFile{Source: src, Issues: []validate.Issue{}}
+		var shown int
+		report := func(issue validate.Issue) error {
+			if *maxIssues > 0 && shown >= *maxIssues {
+				res.Omitted++
+				return nil
+			}
+			shown++
+			switch *format {
+			case validateFormatNDJSON:
+				return enc.Encode(validateIssue{Source: src, Issue: issue})
+			case validateFormatText:
+				return writeIssueText(out, src, issue)
+			}
+			res.Issues = append(res.Issues, issue)
+			return nil
+		}
+		var reportErr error
+		summary, err := validateSource(src, opts, func(issue validate.Issue) error {
+			reportErr = report(issue)
+			return reportErr
+		})
+		if reportErr != nil {
+			return errors.Wrap(reportErr, api)
+		}
+		if err != nil {
+			// файл не удалось открыть или дочитать: проблема файла, остальные файлы проверяются
+			summary.Errors++
+			err = report(validate.Issue{Record: validate.RecordFile, Severity: validate.SeverityError,
+				Code: validate.CodeRead, Message: err.Error()})
+			if err != nil {
+				return errors.Wrap(err, api)
+			}
+		}
+		res.Summary = summary
+		switch *format {
+		case validateFormatJSON:
+			results = append(results, res)
+		case validateFormatText:
+			_, _ = fmt.Fprintf(out, "%s: %d records, %d errors, %d warnings", src, summary.Records, summary.Errors, summary.Warnings)
+			if res.Omitted > 0 {
+				_, _ = fmt.Fprintf(out, " (%d issues not shown)", res.Omitted)
+			}
+			_, _ = fmt.Fprintln(out)
+		}
+		switch summary.Severity() {
+		case validate.SeverityError:
+			worst = validate.SeverityError
+		case validate.SeverityWarning:
+			if worst != validate.SeverityError {
+				worst = validate.SeverityWarning
+			}
+		}
+	}
+	if *format == validateFormatJSON {
+		_ = enc.Encode(struct {
+			Files []validateFile `json:"files"`
+		}{results})
+	}
+	if err := out.Flush(); err != nil {
+		return errors.Wrap(err, api)
+	}
+	switch worst {
+	case validate.SeverityError:
+		return exitErrors
+	case validate.SeverityWarning:
+		return exitWarnings
+	}
+	return nil
+}
+
+func validateSource(src string, opts validate.Options, report func(validate.Issue) error) (validate.Summary, error) {
+	isIndex, err := index.IsIndex(src)
+	if err != nil {
+		return validate.Summary{}, err
+	}
+	if isIndex {
+		return validate.Summary{}, errors.Errorf("'%s' is an index; validate JSON it was built from", src)
+	}
+	f, err := os.Open(src)
+	if err != nil {
+		return validate.Summary{}, err
+	}
+	defer f.Close() //nolint:gosec
+	return validate.Validate(context.Background(), f, opts, report)
+}
+
+func writeIssueText(w *bufio.Writer, src string, issue validate.Issue) error {
+	where := fmt.Sprintf("record %d", issue.Record)
+	if issue.Record == validate.RecordFile {
+		where = "file"
+	}
+	if len(issue.Field) > 0 {
+		where += " " + issue.Field
+	}
+	_, err := fmt.Fprintf(w, "%s: %s: %s %s: %s\n", src, where, issue.Severity, issue.Code, issue.Message)
+	return err
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"sber-test/pkg/diff"
+	"sber-test/pkg/processors"
+	"sber-test/pkg/watch"
+)
+
+// watchParams параметры --watch
+type watchParams struct {
+	enabled  bool
+	poll     bool
+	interval time.Duration
+}
+
+func (p *watchParams) register(fs *flag.FlagSet) {
+	fs.BoolVar(&p.enabled, "watch", false,
+		"rebuild report whenever source, aliases, regions or catalog file changes and print it with changes since previous report")
+	fs.BoolVar(&p.poll, "watch-poll", false, "with --watch: poll files instead of using inotify")
+	fs.DurationVar(&p.interval, "watch-interval", time.Second, "with --watch: polling interval")
+}
+
+// run после каждого изменения files строит отчёт заново и выводит его вместе с изменениями
+// относительно предыдущего; ошибка построения не прерывает наблюдение. Завершается по SIGINT/SIGTERM
+func (p *watchParams) run(files []string, prev processors.RecipeProcessorReport,
+	build func() (processors.RecipeProcessorReport, error), print func(processors.RecipeProcessorReport) error) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	w, err := watch.Watch(ctx, files, watch.Options{Poll: p.poll, Interval: p.interval})
+	if err != nil {
+		return err
+	}
+	log.Printf("watching %s (%s); press Ctrl+C to stop", strings.Join(files, ", "), w.Method)
+	for range w.C {
+		report, err := build()
+		if err != nil {
+			log.Printf("report is not rebuilt: %v", err)
+			continue
+		}
+		_, _ = fmt.Fprintln(os.Stdout)
+		if err = print(report); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(os.Stdout, "\n# %s changes since previous report:\n", time.Now().Format("15:04:05"))
+		if err = diff.WriteText(os.Stdout, diff.Reports(prev, report)); err != nil {
+			return err
+		}
+		prev = report
+	}
+	return nil
+}
@@ -1,9 +1,25 @@
 module sber-test
 
-go 1.16
+go 1.21
 
 require (
+	github.com/BurntSushi/toml v1.2.1
 	github.com/json-iterator/go v1.1.11
-	github.com/stretchr/testify v1.7.0
 	github.com/pkg/errors v0.9.1
-)
\ No newline at end of file
+	github.com/santhosh-tekuri/jsonschema/v5 v5.3.1
+	github.com/stretchr/testify v1.7.0
+	golang.org/x/text v0.14.0
+	google.golang.org/grpc v1.64.0
+	google.golang.org/protobuf v1.34.2
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/modern-go/concurrent v0.0.0-20180228061459-e0a39a4cb421 // indirect
+	github.com/modern-go/reflect2 v0.0.0-20180701023420-4b7aa43c6742 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.22.0 // indirect
+	golang.org/x/sys v0.18.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240318140521-94a12d6c2237 // indirect
+)
@@ -0,0 +1,67 @@
+package internal
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors" //nolint:goimports
+	"sber-test/pkg/models"  //nolint:goimports
+)
+
+// LoadCatalog читает каталог рецептов из CSV (заголовок id,name,category,tags,calories; теги через ';')
+// или JSON ([{"id": .., "name": .., "category": .., "tags": [..], "calories": ..}, ...]) файла
+func LoadCatalog(f string) ([]models.CatalogRecipe, error) {
+	const api = "LoadCatalog"
+
+	file, e := os.Open(f)
+	if e != nil {
+		return nil, errors.Wrapf(e, "%s: open file('%s')", api, f)
+	}
+	defer file.Close() //nolint:gosec
+
+	var ret []models.CatalogRecipe
+	if strings.EqualFold(filepath.Ext(f), ".csv") {
+		ret, e = readCatalogCSV(file)
+	} else {
+		e = json.NewDecoder(file).Decode(&ret)
+	}
+	if e != nil {
+		return nil, errors.Wrapf(e, "%s: decode file('%s')", api, f)
+	}
+	for i := range ret {
+		if len(ret[i].Name) == 0 {
+			return nil, errors.Errorf("%s: recipe #%d has no name in file('%s')", api, i, f)
+		}
+	}
+	return ret, nil
+}
+
+func readCatalogCSV(r io.Reader) ([]models.CatalogRecipe, error) {
+	rows, e := readCSVTable(r, "name")
+	if e != nil {
+		return nil, e
+	}
+	ret := make([]models.CatalogRecipe, 0, len(rows))
+	for _, row := range rows {
+		recipe := models.CatalogRecipe{
+			ID:       row["id"],
+			Name:     row["name"],
+			Category: row["category"],
+		}
+		for _, tag := range strings.Split(row["tags"], ";") {
+			if tag = strings.TrimSpace(tag); len(tag) > 0 {
+				recipe.Tags = append(recipe.Tags, tag)
+			}
+		}
+		if c := row["calories"]; len(c) > 0 {
+			if recipe.Calories, e = strconv.Atoi(c); e != nil {
+				return nil, errors.Wrapf(e, "recipe '%s': calories", recipe.Name)
+			}
+		}
+		ret = append(ret, recipe)
+	}
+	return ret, nil
+}
@@ -0,0 +1,48 @@
+package internal
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// readCSVTable читает CSV с заголовком; каждая строка - отображение имя колонки (в нижнем регистре) -> значение
+func readCSVTable(r io.Reader, required ...string) ([]map[string]string, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, e := reader.Read()
+	if e != nil {
+		return nil, e
+	}
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(header[i]))
+	}
+	for _, name := range required {
+		found := false
+		for _, h := range header {
+			found = found || h == name
+		}
+		if !found {
+			return nil, errors.Errorf("no '%s' column", name)
+		}
+	}
+	var ret []map[string]string
+	for {
+		row, e := reader.Read()
+		if e == io.EOF {
+			return ret, nil
+		}
+		if e != nil {
+			return nil, e
+		}
+		m := make(map[string]string, len(header))
+		for i, h := range header {
+			if i < len(row) {
+				m[h] = strings.TrimSpace(row[i])
+			}
+		}
+		ret = append(ret, m)
+	}
+}
@@ -0,0 +1,43 @@
+// Package fsutil работа с файлами, общая для команд и пакетов
+package fsutil
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// WriteFileAtomic пишет файл через временный файл в том же каталоге и rename, чтобы читатель
+// (например, textfile collector node_exporter) никогда не видел файл записанным наполовину
+func WriteFileAtomic(path string, write func(io.Writer) error) error {
+	const api = "WriteFileAtomic"
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return errors.Wrapf(err, "%s: create temp file for '%s'", api, path)
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+	if err = write(tmp); err != nil {
+		return errors.Wrap(err, api)
+	}
+	if err = tmp.Chmod(0o644); err != nil {
+		return errors.Wrap(err, api)
+	}
+	if err = tmp.Sync(); err != nil {
+		return errors.Wrap(err, api)
+	}
+	if err = tmp.Close(); err != nil {
+		return errors.Wrap(err, api)
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return errors.Wrapf(err, "%s: rename to '%s'", api, path)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package fsutil
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "report.prom")
+	assert.NoError(t, os.WriteFile(path, []byte("old"), 0o600))
+
+	assert.Error(t, WriteFileAtomic(path, func(w io.Writer) error {
+		_, _ = io.WriteString(w, "partial")
+		return errors.New("failed")
+	}))
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "old", string(data))
+
+	assert.NoError(t, WriteFileAtomic(path, func(w io.Writer) error {
+		_, e := io.WriteString(w, "new")
+		return e
+	}))
+	data, err = os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
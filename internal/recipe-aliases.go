@@ -0,0 +1,32 @@
+package internal
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// LoadRecipeAliases читает JSON-файл вида {"каноническое имя": ["вариант", ...], ...}
+// и возвращает отображение вариант -> каноническое имя
+func LoadRecipeAliases(f string) (map[string]string, error) {
+	const api = "LoadRecipeAliases"
+
+	data, e := os.ReadFile(f)
+	if e != nil {
+		return nil, errors.Wrapf(e, "%s: read file('%s')", api, f)
+	}
+	var raw map[string][]string
+	if e = json.Unmarshal(data, &raw); e != nil {
+		return nil, errors.Wrapf(e, "%s: JSON Decode", api)
+	}
+	ret := make(map[string]string)
+	for name, variants := range raw {
+		for _, v := range variants {
+			if prev, ok := ret[v]; ok && prev != name {
+				return nil, errors.Errorf("%s: variant '%s' maps to both '%s' and '%s'", api, v, prev, name)
+			}
+			ret[v] = name
+		}
+	}
+	return ret, nil
+}
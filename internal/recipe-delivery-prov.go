@@ -6,6 +6,7 @@ import (
 
 	jsoniter "github.com/json-iterator/go" //nolint:goimports
 	"github.com/pkg/errors"
+	"sber-test/pkg/index"
 	"sber-test/pkg/models"
 	"sber-test/pkg/providers" //nolint:goimports
 )
@@ -46,3 +47,22 @@ func (p *recipeDeliveryProvider) Provide(_ context.Context, consumer func(models
 	}
 	return nil
 }
+
+// OpenSource провайдер доставок файла: индекс, построенный командой index, загружается в память,
+// JSON читается при каждом Provide
+func OpenSource(f string) (providers.RecipeDeliveryProvider, error) {
+	const api = "OpenSource"
+
+	isIndex, e := index.IsIndex(f)
+	if e != nil {
+		return nil, errors.Wrap(e, api)
+	}
+	if !isIndex {
+		return NewRecipeDeliveryProviderFromFile(f), nil
+	}
+	idx, e := index.Load(f)
+	if e != nil {
+		return nil, errors.Wrap(e, api)
+	}
+	return idx, nil
+}
@@ -0,0 +1,68 @@
+package internal
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors" //nolint:goimports
+	"sber-test/pkg/models"  //nolint:goimports
+)
+
+type regionRecord struct {
+	Postcode string `json:"postcode"`
+	models.Region
+}
+
+// LoadRegions читает таблицу "postcode" -> зона из CSV (заголовок postcode,zone,city,depot)
+// или JSON ([{"postcode": "..", "zone": "..", "city": "..", "depot": ".."}, ...]) файла
+func LoadRegions(f string) (map[string]models.Region, error) {
+	const api = "LoadRegions"
+
+	file, e := os.Open(f)
+	if e != nil {
+		return nil, errors.Wrapf(e, "%s: open file('%s')", api, f)
+	}
+	defer file.Close() //nolint:gosec
+
+	var records []regionRecord
+	if strings.EqualFold(filepath.Ext(f), ".csv") {
+		records, e = readRegionsCSV(file)
+	} else {
+		e = json.NewDecoder(file).Decode(&records)
+	}
+	if e != nil {
+		return nil, errors.Wrapf(e, "%s: decode file('%s')", api, f)
+	}
+	ret := make(map[string]models.Region, len(records))
+	for _, r := range records {
+		if len(r.Postcode) == 0 {
+			return nil, errors.Errorf("%s: empty postcode in file('%s')", api, f)
+		}
+		if prev, ok := ret[r.Postcode]; ok && prev != r.Region {
+			return nil, errors.Errorf("%s: postcode '%s' is mapped twice", api, r.Postcode)
+		}
+		ret[r.Postcode] = r.Region
+	}
+	return ret, nil
+}
+
+func readRegionsCSV(r io.Reader) ([]regionRecord, error) {
+	rows, e := readCSVTable(r, "postcode")
+	if e != nil {
+		return nil, e
+	}
+	ret := make([]regionRecord, 0, len(rows))
+	for _, row := range rows {
+		ret = append(ret, regionRecord{
+			Postcode: row["postcode"],
+			Region: models.Region{
+				Zone:  row["zone"],
+				City:  row["city"],
+				Depot: row["depot"],
+			},
+		})
+	}
+	return ret, nil
+}
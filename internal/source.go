@@ -0,0 +1,37 @@
+package internal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"    //nolint:goimports
+	"sber-test/pkg/formatters" //nolint:goimports
+)
+
+// DescribeSource метаданные файла источника для конверта отчёта: размер, время изменения и SHA-256
+func DescribeSource(f string) (*formatters.ReportSource, error) {
+	const api = "DescribeSource"
+
+	file, e := os.Open(f)
+	if e != nil {
+		return nil, errors.Wrapf(e, "%s: open file('%s')", api, f)
+	}
+	defer file.Close() //nolint:gosec
+
+	info, e := file.Stat()
+	if e != nil {
+		return nil, errors.Wrapf(e, "%s: stat file('%s')", api, f)
+	}
+	h := sha256.New()
+	if _, e = io.Copy(h, file); e != nil {
+		return nil, errors.Wrapf(e, "%s: read file('%s')", api, f)
+	}
+	return &formatters.ReportSource{
+		Path:    f,
+		Size:    info.Size(),
+		ModTime: info.ModTime().UTC(),
+		SHA256:  hex.EncodeToString(h.Sum(nil)),
+	}, nil
+}
@@ -0,0 +1,280 @@
+// Package anomaly поиск строк отчёта (рецептов, postcode, групп --group-by), значения которых
+// сильно отклоняются от ожидаемых: по истории периодов или по базовому отчёту
+package anomaly
+
+import (
+	"math"
+	"sort"
+
+	"github.com/pkg/errors" //nolint:goimports
+	"sber-test/pkg/processors"
+	"sber-test/pkg/trend" //nolint:goimports
+)
+
+// Method оценка центра и разброса значений
+type Method string
+
+// methods
+const (
+	// MethodMAD медиана и медианное абсолютное отклонение (устойчиво к выбросам в истории)
+	MethodMAD Method = "mad"
+	// MethodZScore среднее и стандартное отклонение
+	MethodZScore Method = "zscore"
+)
+
+// Kind направление отклонения
+type Kind string
+
+// kinds
+const (
+	KindSpike Kind = "spike"
+	KindDrop  Kind = "drop"
+)
+
+// DefaultMinHistory минимальное число значений для оценки разброса
+const DefaultMinHistory = 3
+
+type (
+	// Options параметры поиска; нулевые значения заменяются значениями по умолчанию
+	Options struct {
+		Method Method
+		// Threshold допустимое отклонение в единицах разброса; по умолчанию DefaultThreshold(Method)
+		Threshold float64
+		// MinHistory минимум периодов истории (FromTrend) или строк раздела (FromBaseline), не меньше 2
+		MinHistory int
+	}
+
+	// Anomaly значение столбца Column строки Key раздела Section вне ожидаемого диапазона [Low, High]
+	Anomaly struct {
+		Section    string   `json:"section"`
+		KeyColumns []string `json:"key_columns,omitempty"`
+		Key        []string `json:"key,omitempty"`
+		Column     string   `json:"column"`
+		Observed   float64  `json:"observed"`
+		Expected   float64  `json:"expected"`
+		Low        float64  `json:"low"`
+		High       float64  `json:"high"`
+		// Score отклонение в единицах разброса
+		Score float64 `json:"score"`
+		Kind  Kind    `json:"kind"`
+		// Label нечисловые столбцы строки, например "postcode" в busiest_postcode
+		Label string `json:"label,omitempty"`
+	}
+)
+
+// ParseMethod ...
+func ParseMethod(s string) (Method, error) {
+	switch m := Method(s); m {
+	case MethodMAD, MethodZScore:
+		return m, nil
+	}
+	return "", errors.Errorf("unknown method '%s'; use %s or %s", s, MethodMAD, MethodZScore)
+}
+
+// DefaultThreshold 3.5 для MAD (Iglewicz-Hoaglin), 3 для z-score
+func DefaultThreshold(m Method) float64 {
+	if m == MethodZScore {
+		return 3
+	}
+	return 3.5
+}
+
+// FromTrend сравнивает значение каждого ряда в последнем периоде с его значениями в предыдущих
+// периодах. Строки, которой нет в отчёте периода, - 0 (см. trend.Build); периоды, где значение
+// неизвестно (раздел обрезан --limit), не учитываются, ряды без значения в последнем периоде или
+// с историей короче MinHistory пропускаются
+func FromTrend(t trend.Trend, opts Options) []Anomaly {
+	opts = opts.withDefaults()
+	var ret []Anomaly
+	for _, s := range t.Series {
+		if skipColumns[s.Column] || len(s.Points) < opts.MinHistory+1 {
+			continue
+		}
+		last := s.Points[len(s.Points)-1]
+		if last.Value == nil {
+			continue
+		}
+		history := make([]float64, 0, len(s.Points)-1)
+		for _, p := range s.Points[:len(s.Points)-1] {
+			if p.Value != nil {
+				history = append(history, *p.Value)
+			}
+		}
+		if len(history) < opts.MinHistory {
+			continue
+		}
+		center, scale := opts.estimate(history)
+		// значения - количества доставок: разброс меньше одной доставки - шум
+		scale = math.Max(scale, minCountScale)
+		a, ok := opts.check(*last.Value, center, scale, *last.Value, identity)
+		if !ok {
+			continue
+		}
+		a.Section, a.KeyColumns, a.Key, a.Column, a.Label = s.Section, s.KeyColumns, s.Key, s.Column, last.Label
+		ret = append(ret, a)
+	}
+	return ret
+}
+
+// FromBaseline сравнивает current с baseline: для каждого показателя раздела оценивает общее
+// изменение строк (логарифм отношения current/baseline) и отмечает строки, изменившиеся
+// непропорционально остальным. Строки, которой нет в одном из отчётов, - 0, как в FromTrend;
+// строки с неизвестным значением (раздел обрезан --limit) не учитываются. Разделы с числом
+// строк меньше MinHistory пропускаются
+func FromBaseline(baseline, current processors.RecipeProcessorReport, opts Options) []Anomaly {
+	opts = opts.withDefaults()
+	t := trend.Build(make([]trend.Period, 2), []processors.RecipeProcessorReport{baseline, current})
+	var ret []Anomaly
+	for i := 0; i < len(t.Series); {
+		// ряды одного показателя идут подряд
+		j := i + 1
+		for j < len(t.Series) && t.Series[j].Section == t.Series[i].Section && t.Series[j].Column == t.Series[i].Column {
+			j++
+		}
+		ret = append(ret, opts.crossSection(t.Series[i:j])...)
+		i = j
+	}
+	return ret
+}
+
+// ---------------------------------------- IMPL -------------------------------------
+
+// skipColumns столбцы, не описывающие объём доставок
+var skipColumns = map[string]bool{"error": true}
+
+// minCountScale минимальный разброс количеств в FromTrend: иначе у постоянной истории
+// аномалией считается любое изменение
+const minCountScale = 1
+
+// minRatioScale то же для логарифмов отношений в FromBaseline: около 5%
+const minRatioScale = 0.05
+
+// madScale приводит MAD к стандартному отклонению нормального распределения
+const madScale = 1.4826
+
+// meanADScale то же для среднего абсолютного отклонения
+const meanADScale = 1.2533
+
+func identity(v float64) float64 {
+	return v
+}
+
+func (o Options) withDefaults() Options {
+	if len(o.Method) == 0 {
+		o.Method = MethodMAD
+	}
+	if o.Threshold <= 0 {
+		o.Threshold = DefaultThreshold(o.Method)
+	}
+	if o.MinHistory <= 0 {
+		o.MinHistory = DefaultMinHistory
+	}
+	// разброс оценивается минимум по двум значениям
+	o.MinHistory = max(o.MinHistory, 2)
+	return o
+}
+
+func (o Options) crossSection(all []trend.Series) []Anomaly {
+	if skipColumns[all[0].Column] {
+		return nil
+	}
+	series := make([]trend.Series, 0, len(all))
+	for _, s := range all {
+		if s.Points[0].Value != nil && s.Points[1].Value != nil {
+			series = append(series, s)
+		}
+	}
+	if len(series) < o.MinHistory {
+		return nil
+	}
+	ratios := make([]float64, len(series))
+	for i, s := range series {
+		ratios[i] = logRatio(*s.Points[0].Value, *s.Points[1].Value)
+	}
+	center, scale := o.estimate(ratios)
+	scale = math.Max(scale, minRatioScale)
+	var ret []Anomaly
+	for i, s := range series {
+		base := *s.Points[0].Value
+		// ожидаемое значение - базовое, изменившееся так же, как у типичной строки
+		toValue := func(r float64) float64 {
+			return (base+1)*math.Exp(r) - 1
+		}
+		a, ok := o.check(ratios[i], center, scale, *s.Points[1].Value, toValue)
+		if !ok {
+			continue
+		}
+		a.Section, a.KeyColumns, a.Key, a.Column, a.Label = s.Section, s.KeyColumns, s.Key, s.Column, s.Points[1].Label
+		ret = append(ret, a)
+	}
+	return ret
+}
+
+// check отклонение x от center; toValue переводит x, center и границы диапазона в значения
+// показателя, observed - наблюдаемое значение показателя
+func (o Options) check(x, center, scale, observed float64, toValue func(float64) float64) (Anomaly, bool) {
+	a := Anomaly{
+		Observed: round(observed),
+		Expected: round(toValue(center)),
+		Low:      round(math.Max(0, toValue(center-o.Threshold*scale))),
+		High:     round(toValue(center + o.Threshold*scale)),
+		Kind:     KindSpike,
+	}
+	if x < center {
+		a.Kind = KindDrop
+	}
+	score := (x - center) / scale
+	if math.Abs(score) <= o.Threshold {
+		return a, false
+	}
+	a.Score = round(score)
+	return a, true
+}
+
+// estimate центр и разброс values
+func (o Options) estimate(values []float64) (center, scale float64) {
+	if o.Method == MethodZScore {
+		for _, v := range values {
+			center += v
+		}
+		center /= float64(len(values))
+		var ss float64
+		for _, v := range values {
+			ss += (v - center) * (v - center)
+		}
+		return center, math.Sqrt(ss / float64(len(values)-1))
+	}
+	center = median(values)
+	deviations := make([]float64, len(values))
+	for i, v := range values {
+		deviations[i] = math.Abs(v - center)
+	}
+	if mad := median(deviations); mad > 0 {
+		return center, madScale * mad
+	}
+	// больше половины значений совпадают: среднее абсолютное отклонение
+	var sum float64
+	for _, d := range deviations {
+		sum += d
+	}
+	return center, meanADScale * sum / float64(len(deviations))
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// logRatio логарифм отношения со сглаживанием, чтобы учесть строки, которых нет в одном из отчётов
+func logRatio(from, to float64) float64 {
+	return math.Log((to + 1) / (from + 1))
+}
+
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
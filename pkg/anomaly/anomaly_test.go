@@ -0,0 +1,155 @@
+package anomaly
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert" //nolint:goimports
+	"sber-test/pkg/processors"
+	"sber-test/pkg/trend" //nolint:goimports
+)
+
+// counts отчёт count_per_recipe и busiest_postcode: рецепты "R0", "R1", .. с количествами counts
+func counts(t *testing.T, busiest int, counts ...int) processors.RecipeProcessorReport {
+	items := make([]string, 0, len(counts))
+	for i, c := range counts {
+		items = append(items, fmt.Sprintf(`{"recipe":"R%d","count":%d}`, i, c))
+	}
+	var ret processors.RecipeProcessorReport
+	assert.NoError(t, json.Unmarshal([]byte(fmt.Sprintf(
+		`{"count_per_recipe":[%s],"busiest_postcode":{"postcode":"10%d","delivery_count":%d}}`,
+		strings.Join(items, ","), busiest%2, busiest)), &ret))
+	return ret
+}
+
+func history(reports ...processors.RecipeProcessorReport) trend.Trend {
+	return trend.Build(make([]trend.Period, len(reports)), reports)
+}
+
+func TestFromTrend(t *testing.T) {
+	tr := history(
+		counts(t, 50, 100, 10, 7),
+		counts(t, 52, 104, 12, 7),
+		counts(t, 49, 98, 11, 8),
+		counts(t, 51, 102, 10, 7),
+		counts(t, 50, 99, 40, 0),
+	)
+	got := FromTrend(tr, Options{})
+	assert.Len(t, got, 2)
+	spike := got[0]
+	assert.Equal(t, "count_per_recipe", spike.Section)
+	assert.Equal(t, []string{"recipe"}, spike.KeyColumns)
+	assert.Equal(t, []string{"R1"}, spike.Key)
+	assert.Equal(t, KindSpike, spike.Kind)
+	assert.Equal(t, float64(40), spike.Observed)
+	assert.Equal(t, 10.5, spike.Expected)
+	assert.True(t, spike.Low < 10 && spike.High > 12 && spike.High < 40, spike)
+	assert.True(t, spike.Score > 3.5, spike)
+
+	// MAD истории 7, 7, 8, 7 нулевой: разброс по среднему абсолютному отклонению
+	drop := got[1]
+	assert.Equal(t, []string{"R2"}, drop.Key)
+	assert.Equal(t, KindDrop, drop.Kind)
+	assert.Equal(t, float64(0), drop.Observed)
+	assert.Equal(t, float64(7), drop.Expected)
+	assert.True(t, drop.Score < -3.5, drop)
+
+	// история короче MinHistory
+	assert.Empty(t, FromTrend(history(counts(t, 1, 10), counts(t, 1, 10), counts(t, 1, 100)), Options{}))
+	assert.Len(t, FromTrend(history(counts(t, 1, 10), counts(t, 1, 11), counts(t, 1, 100)), Options{MinHistory: 2}), 1)
+	// рецепт R1 пропал в последнем периоде: 0, сильнейшее падение
+	gone := FromTrend(history(counts(t, 1, 10, 100), counts(t, 1, 10, 102), counts(t, 1, 10, 98), counts(t, 1, 10)), Options{})
+	assert.Len(t, gone, 1)
+	assert.Equal(t, []string{"R1"}, gone[0].Key)
+	assert.Equal(t, KindDrop, gone[0].Kind)
+	assert.Equal(t, float64(0), gone[0].Observed)
+	assert.Equal(t, float64(100), gone[0].Expected)
+	// в обрезанной --limit группировке пропавшая строка не известна, а не 0
+	top := func(rows string) processors.RecipeProcessorReport {
+		var ret processors.RecipeProcessorReport
+		assert.NoError(t, json.Unmarshal([]byte(`{"aggregations":[{"group_by":["postcode"],"rows":[`+rows+`],"truncated":true}]}`), &ret))
+		return ret
+	}
+	row := func(postcode string, count int) string {
+		return fmt.Sprintf(`{"key":["%s"],"count":%d}`, postcode, count)
+	}
+	assert.Empty(t, FromTrend(history(
+		top(row("1", 100)), top(row("1", 102)), top(row("1", 98)), top(row("2", 101)),
+	), Options{}))
+	// постоянная история: разброс не меньше одной доставки, изменение на одну - не аномалия
+	assert.Empty(t, FromTrend(history(counts(t, 5, 10), counts(t, 5, 10), counts(t, 5, 10), counts(t, 6, 10)), Options{}))
+	constant := FromTrend(history(counts(t, 5, 10), counts(t, 5, 10), counts(t, 5, 10), counts(t, 50, 10)), Options{})
+	assert.Len(t, constant, 1)
+	assert.Equal(t, "busiest_postcode", constant[0].Section)
+	assert.Equal(t, "100", constant[0].Label)
+	assert.Equal(t, float64(45), constant[0].Score)
+	assert.Equal(t, 1.5, constant[0].Low)
+	assert.Equal(t, 8.5, constant[0].High)
+}
+
+func TestFromTrendZScore(t *testing.T) {
+	tr := history(
+		counts(t, 1, 100), counts(t, 1, 110), counts(t, 1, 90), counts(t, 1, 100), counts(t, 1, 125),
+	)
+	// z-score (125-100)/8.16 = 3.06 > 3
+	got := FromTrend(tr, Options{Method: MethodZScore})
+	assert.Len(t, got, 1)
+	assert.Equal(t, 3.06, got[0].Score)
+	assert.Empty(t, FromTrend(tr, Options{Method: MethodZScore, Threshold: 3.5}))
+}
+
+func TestFromBaseline(t *testing.T) {
+	// все рецепты выросли примерно вдвое, кроме R3 (в 10 раз) и R5 (пропал)
+	baseline := counts(t, 50, 100, 200, 50, 30, 80, 60)
+	current := counts(t, 100, 205, 390, 100, 310, 165, 0)
+	got := FromBaseline(baseline, current, Options{})
+	assert.Len(t, got, 2)
+	assert.Equal(t, []string{"R3"}, got[0].Key)
+	assert.Equal(t, KindSpike, got[0].Kind)
+	assert.Equal(t, float64(310), got[0].Observed)
+	assert.InDelta(t, 61, got[0].Expected, 1)
+	assert.Equal(t, []string{"R5"}, got[1].Key)
+	assert.Equal(t, KindDrop, got[1].Kind)
+	assert.InDelta(t, 121, got[1].Expected, 2)
+
+	// один busiest_postcode не с чем сравнивать; строк меньше MinHistory
+	assert.Empty(t, FromBaseline(counts(t, 1, 10, 10), counts(t, 100, 10, 100), Options{}))
+}
+
+func TestParseMethod(t *testing.T) {
+	m, err := ParseMethod("zscore")
+	assert.NoError(t, err)
+	assert.Equal(t, MethodZScore, m)
+	_, err = ParseMethod("iqr")
+	assert.Error(t, err)
+}
+
+func TestWrite(t *testing.T) {
+	anomalies := []Anomaly{
+		{Section: "count_per_recipe", KeyColumns: []string{"recipe"}, Key: []string{"R1"}, Column: "count",
+			Observed: 40, Expected: 10.5, Low: 7.2, High: 13.8, Score: 4.5, Kind: KindSpike},
+		{Section: "busiest_postcode", Column: "delivery_count", Observed: 50, Expected: 5, Low: 1.5, High: 8.5,
+			Score: 45, Kind: KindSpike, Label: "100"},
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, WriteTable(&buf, anomalies))
+	assert.Equal(t, `Anomalies
+section           key    column          observed  expected  range      score  kind
+count_per_recipe  R1     count           40        10.5      7.2..13.8  +4.5   spike
+busiest_postcode  (100)  delivery_count  50        5         1.5..8.5   +45    spike
+`, buf.String())
+
+	buf.Reset()
+	assert.NoError(t, WriteCSV(&buf, anomalies))
+	assert.Equal(t, `section,key,column,observed,expected,low,high,score,kind,label
+count_per_recipe,R1,count,40,10.5,7.2,13.8,+4.5,spike,
+busiest_postcode,,delivery_count,50,5,1.5,8.5,+45,spike,100
+`, buf.String())
+
+	buf.Reset()
+	assert.NoError(t, WriteTable(&buf, nil))
+	assert.Equal(t, "no anomalies\n", buf.String())
+}
@@ -0,0 +1,65 @@
+package anomaly
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"sber-test/pkg/formatters"
+)
+
+// WriteTable раздел "Anomalies": строка на аномалию или "no anomalies"
+func WriteTable(w io.Writer, anomalies []Anomaly) error {
+	bw := bufio.NewWriter(w)
+	if len(anomalies) == 0 {
+		_, _ = fmt.Fprintln(bw, "no anomalies")
+		return bw.Flush()
+	}
+	_, _ = fmt.Fprintln(bw, "Anomalies")
+	tw := tabwriter.NewWriter(bw, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "section\tkey\tcolumn\tobserved\texpected\trange\tscore\tkind")
+	for _, a := range anomalies {
+		key := strings.Join(a.Key, ", ")
+		if len(a.Label) > 0 {
+			key = strings.TrimPrefix(key+" ("+a.Label+")", " ")
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s..%s\t%s\t%s\n", a.Section, key, a.Column,
+			number(a.Observed), number(a.Expected), number(a.Low), number(a.High), signed(a.Score), a.Kind)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// WriteCSV строка на аномалию
+func WriteCSV(w io.Writer, anomalies []Anomaly) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"section", "key", "column", "observed", "expected", "low", "high", "score", "kind", "label"})
+	for _, a := range anomalies {
+		_ = cw.Write([]string{
+			a.Section, formatters.EscapeCSVCell(strings.Join(a.Key, ", ")), a.Column, number(a.Observed), number(a.Expected),
+			number(a.Low), number(a.High), signed(a.Score), string(a.Kind), formatters.EscapeCSVCell(a.Label),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ---------------------------------------- IMPL -------------------------------------
+
+func number(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func signed(v float64) string {
+	s := number(v)
+	if v > 0 {
+		s = "+" + s
+	}
+	return s
+}
@@ -0,0 +1,167 @@
+// Package cache дисковый кэш отчётов: отчёт хранится под ключом, вычисленным по отпечаткам
+// файлов-источников и нормализованным параметрам отчёта
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors" //nolint:goimports
+	"sber-test/internal/fsutil"
+	"sber-test/pkg/formatters"
+	"sber-test/pkg/processors" //nolint:goimports
+)
+
+// formatVersion версия формата записей кэша; меняется вместе с ключом, поэтому записи старого
+// формата просто перестают находиться и со временем удаляются Prune
+const formatVersion = "1"
+
+// buildVersion версия сборки программы (версия модуля и ревизия VCS); входит в ключ, чтобы отчёты,
+// построенные другой сборкой (с другой логикой подсчёта), не брались из кэша
+var buildVersion = readBuildVersion()
+
+// Cache кэш отчётов в каталоге dir
+type Cache struct {
+	dir string
+}
+
+// DefaultDir <каталог кэша пользователя>/sber-test
+func DefaultDir() (string, error) {
+	const api = "cache.DefaultDir"
+
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", errors.Wrap(err, api)
+	}
+	return filepath.Join(dir, "sber-test"), nil
+}
+
+// New ...
+func New(dir string) *Cache {
+	return &Cache{dir: dir}
+}
+
+// Key ключ отчёта по отпечаткам источников (FingerprintFile) и параметрам; порядок параметров
+// в map не важен, порядок отпечатков важен
+func Key(fingerprints []string, params map[string]string) string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	h := sha256.New()
+	write := func(s string) {
+		_, _ = io.WriteString(h, s)
+		_, _ = h.Write([]byte{0})
+	}
+	write(formatVersion)
+	write(formatters.ReportSchemaVersion)
+	write(buildVersion)
+	for _, fp := range fingerprints {
+		write(fp)
+	}
+	for _, name := range names {
+		write(name)
+		write(params[name])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Get отчёт из кэша; испорченная запись считается отсутствующей и удаляется
+func (c *Cache) Get(key string) (processors.RecipeProcessorReport, bool) {
+	var report processors.RecipeProcessorReport
+	path := c.path(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return report, false
+	}
+	if err = json.Unmarshal(data, &report); err != nil {
+		_ = os.Remove(path)
+		return processors.RecipeProcessorReport{}, false
+	}
+	now := time.Now()
+	_ = os.Chtimes(path, now, now) // Prune удаляет давно не использованные записи
+	return report, true
+}
+
+// Put сохраняет отчёт
+func (c *Cache) Put(key string, report processors.RecipeProcessorReport) error {
+	const api = "Cache.Put"
+
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return errors.Wrapf(err, "%s: create dir('%s')", api, c.dir)
+	}
+	data, err := json.Marshal(report)
+	if err != nil {
+		return errors.Wrap(err, api)
+	}
+	err = fsutil.WriteFileAtomic(c.path(key), func(w io.Writer) error {
+		_, e := w.Write(data)
+		return e
+	})
+	return errors.Wrap(err, api)
+}
+
+// Prune удаляет записи, не использованные дольше maxAge (0 - все записи), и возвращает их число
+func (c *Cache) Prune(maxAge time.Duration) (int, error) {
+	const api = "Cache.Prune"
+
+	entries, err := os.ReadDir(c.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Wrapf(err, "%s: read dir('%s')", api, c.dir)
+	}
+	deadline := time.Now().Add(-maxAge)
+	removed := 0
+	for _, e := range entries {
+		name := e.Name()
+		// записи и недописанные временные файлы WriteFileAtomic
+		isEntry := strings.HasSuffix(name, ".json") || strings.HasSuffix(name, ".tmp")
+		if e.IsDir() || !isEntry {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		if maxAge > 0 && info.ModTime().After(deadline) {
+			continue
+		}
+		if err = os.Remove(filepath.Join(c.dir, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return removed, errors.Wrap(err, api)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// ---------------------------------------- IMPL -------------------------------------
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+func readBuildVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	parts := []string{info.Main.Version}
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision", "vcs.time", "vcs.modified":
+			parts = append(parts, s.Key+"="+s.Value)
+		}
+	}
+	return strings.Join(parts, " ")
+}
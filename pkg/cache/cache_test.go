@@ -0,0 +1,148 @@
+package cache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" //nolint:goimports
+	"sber-test/pkg/models"
+	"sber-test/pkg/processors"
+	"sber-test/pkg/providers"
+	ts "sber-test/pkg/time-slot" //nolint:goimports
+)
+
+var testData = providers.RecipeDeliveries{
+	{Recipe: "Ink | Pen", Postcode: "10120", Delivery: ts.ConstructDelivery(time.Monday, 10, 15)},
+	{Recipe: "ink  pen", Postcode: "10120", Delivery: ts.ConstructDelivery(time.Thursday, 10, 15)},
+	{Recipe: "B Potato", Postcode: "x2", Delivery: ts.ConstructDelivery(time.Wednesday, 8, 15)},
+	{Recipe: "Veggie Potato", Postcode: "10121", Delivery: ts.ConstructDelivery(time.Monday, 7, 9)},
+}
+
+// testReport отчёт со всеми разделами
+func testReport(t *testing.T) processors.RecipeProcessorReport {
+	agg, err := processors.ReportGroupBy(processors.GroupBySpec{By: []processors.Dimension{processors.DimZone}, CountDistinct: processors.DimRecipe})
+	assert.NoError(t, err)
+	regions, err := processors.EnrichRegions(map[string]models.Region{"10120": {Zone: "North", City: "Oslo", Depot: "D1"}}, processors.DimNone)
+	assert.NoError(t, err)
+	catalog, err := processors.JoinCatalog([]models.CatalogRecipe{{Name: "Ink | Pen", Category: "office", Tags: []string{"vegan"}}})
+	assert.NoError(t, err)
+	report, err := processors.NewRecipeReportProcessor(
+		processors.ReportUniqueRecipesApprox(0.01), processors.ReportCounterPerRecipe(),
+		processors.ReportBusiestPostcodeApprox(0.01, 0),
+		processors.ReportDeliveryCountForPostcodeAndTime("10120", 9, 16),
+		processors.ReportIfMatchedRecipes("Potato"), agg,
+		processors.ReportCountPerCategory(), processors.ReportCountPerTag(), processors.ReportTagShare("vegan"),
+	).WithStages(
+		processors.NormalizeRecipes(nil),
+		processors.ValidatePostcodes(processors.PostcodeFormat(regexp.MustCompile(`^[0-9]+$`))),
+		regions, catalog,
+	).Process(context.Background(), testData)
+	assert.NoError(t, err)
+	return report
+}
+
+func TestCacheRoundTrip(t *testing.T) {
+	c := New(filepath.Join(t.TempDir(), "cache"))
+	key := Key([]string{"sha256:00"}, map[string]string{"count-per-recipe": "true"})
+	_, ok := c.Get(key)
+	assert.False(t, ok)
+
+	want := testReport(t)
+	assert.NoError(t, c.Put(key, want))
+	got, ok := c.Get(key)
+	assert.True(t, ok)
+	assert.Equal(t, want, got)
+
+	empty, err := processors.NewRecipeReportProcessor(processors.ReportIfMatchedRecipes("Nope")).
+		Process(context.Background(), testData)
+	assert.NoError(t, err)
+	assert.NoError(t, c.Put(key, empty))
+	got, ok = c.Get(key)
+	assert.True(t, ok)
+	assert.Equal(t, []string{}, got.RecipesMatchedByName)
+	assert.Nil(t, got.CountPerRecipe)
+}
+
+func TestCacheCorruptedEntry(t *testing.T) {
+	c := New(t.TempDir())
+	key := Key(nil, nil)
+	assert.NoError(t, os.WriteFile(c.path(key), []byte(`{"count_per_postcode_and_time":{"from":"25PM"}}`), 0o600))
+	_, ok := c.Get(key)
+	assert.False(t, ok)
+	_, err := os.Stat(c.path(key))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestKey(t *testing.T) {
+	params := map[string]string{"count-per-recipe": "true", "find-recipes": "Potato"}
+	k := Key([]string{"a", "b"}, params)
+	assert.Equal(t, k, Key([]string{"a", "b"}, map[string]string{"find-recipes": "Potato", "count-per-recipe": "true"}))
+	assert.NotEqual(t, k, Key([]string{"b", "a"}, params))
+	assert.NotEqual(t, k, Key([]string{"a", "b"}, map[string]string{"count-per-recipe": "true"}))
+	// разделитель не даёт склеить соседние значения
+	assert.NotEqual(t, Key([]string{"ab", ""}, nil), Key([]string{"a", "b"}, nil))
+
+	// другая сборка программы - другой ключ
+	prev := buildVersion
+	buildVersion = "v0.0.0-other"
+	defer func() { buildVersion = prev }()
+	assert.NotEqual(t, k, Key([]string{"a", "b"}, params))
+}
+
+func TestPrune(t *testing.T) {
+	dir := t.TempDir()
+	c := New(dir)
+	report := testReport(t)
+	assert.NoError(t, c.Put("old", report))
+	assert.NoError(t, c.Put("new", report))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "keep.txt"), nil, 0o600))
+	old := time.Now().Add(-48 * time.Hour)
+	assert.NoError(t, os.Chtimes(c.path("old"), old, old))
+
+	removed, err := c.Prune(24 * time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	_, ok := c.Get("old")
+	assert.False(t, ok)
+	_, ok = c.Get("new")
+	assert.True(t, ok)
+
+	removed, err = c.Prune(0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	_, err = os.Stat(filepath.Join(dir, "keep.txt"))
+	assert.NoError(t, err)
+
+	removed, err = New(filepath.Join(dir, "missing")).Prune(0)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, removed)
+}
+
+func TestFingerprintFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`[]`), 0o600))
+	for _, mode := range []FingerprintMode{FingerprintHash, FingerprintStat} {
+		before, err := FingerprintFile(path, mode)
+		assert.NoError(t, err)
+		same, err := FingerprintFile(path, mode)
+		assert.NoError(t, err)
+		assert.Equal(t, before, same)
+
+		assert.NoError(t, os.WriteFile(path, []byte(`[{}]`), 0o600))
+		after, err := FingerprintFile(path, mode)
+		assert.NoError(t, err)
+		assert.NotEqual(t, before, after)
+	}
+	_, err := FingerprintFile(path+".missing", FingerprintHash)
+	assert.Error(t, err)
+
+	mode, err := ParseFingerprintMode("stat")
+	assert.NoError(t, err)
+	assert.Equal(t, FingerprintStat, mode)
+	_, err = ParseFingerprintMode("mtime")
+	assert.Error(t, err)
+}
@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// FingerprintMode как определять, что файл не изменился
+type FingerprintMode int
+
+const (
+	// FingerprintHash SHA-256 содержимого
+	FingerprintHash FingerprintMode = iota
+	// FingerprintStat размер и время изменения: без чтения файла, но не замечает изменений,
+	// сохранивших размер и время
+	FingerprintStat
+)
+
+var fingerprintModes = map[string]FingerprintMode{
+	"hash": FingerprintHash,
+	"stat": FingerprintStat,
+}
+
+// ParseFingerprintMode hash или stat
+func ParseFingerprintMode(s string) (FingerprintMode, error) {
+	if m, ok := fingerprintModes[s]; ok {
+		return m, nil
+	}
+	return 0, errors.Errorf("unknown fingerprint mode '%s'; expected hash or stat", s)
+}
+
+// FingerprintFile отпечаток содержимого файла
+func FingerprintFile(path string, mode FingerprintMode) (string, error) {
+	const api = "cache.FingerprintFile"
+
+	f, err := os.Open(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "%s: open file('%s')", api, path)
+	}
+	defer f.Close() //nolint:gosec
+	if mode == FingerprintStat {
+		info, err := f.Stat()
+		if err != nil {
+			return "", errors.Wrapf(err, "%s: stat file('%s')", api, path)
+		}
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return "", errors.Wrap(err, api)
+		}
+		// без содержимого разные файлы различаются только путём
+		return fmt.Sprintf("stat:%s:%d:%d", abs, info.Size(), info.ModTime().UnixNano()), nil
+	}
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", errors.Wrapf(err, "%s: read file('%s')", api, path)
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
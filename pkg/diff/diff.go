@@ -0,0 +1,176 @@
+// Package diff сравнение двух отчётов RecipeProcessorReport по разделам и строкам
+package diff
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"sber-test/pkg/formatters"
+	"sber-test/pkg/processors" //nolint:goimports
+)
+
+// Kind вид изменения
+type Kind string
+
+// Kinds
+const (
+	Added   Kind = "added"
+	Removed Kind = "removed"
+	Changed Kind = "changed"
+)
+
+type (
+	// Change изменение раздела отчёта или его строки
+	Change struct {
+		// Section имя раздела, как в formatters.Section
+		Section string `json:"section"`
+		// Key значения ключевых столбцов строки (например, "recipe name"); пусто - раздел целиком
+		// или раздел из одной строки
+		Key     []string       `json:"key,omitempty"`
+		Kind    Kind           `json:"kind"`
+		Columns []ColumnChange `json:"columns,omitempty"`
+	}
+
+	// ColumnChange значение столбца до и после; для добавленных строк Old пусто, для удалённых - New
+	ColumnChange struct {
+		Column string `json:"column"`
+		Old    string `json:"old,omitempty"`
+		New    string `json:"new,omitempty"`
+		// Delta New - Old для числовых столбцов
+		Delta *float64 `json:"delta,omitempty"`
+		// Percent Delta в процентах от Old; нет, если Old равно 0
+		Percent *float64 `json:"percent,omitempty"`
+	}
+)
+
+// Reports изменения от отчёта old к отчёту new в порядке разделов отчёта; строки разделов
+// сопоставляются по ключевым столбцам
+func Reports(old, new processors.RecipeProcessorReport) []Change {
+	oldSections := formatters.Sections(old)
+	newSections := formatters.Sections(new)
+	byName := make(map[string]formatters.Section, len(oldSections))
+	for _, s := range oldSections {
+		byName[s.Name] = s
+	}
+	var ret []Change
+	seen := make(map[string]bool, len(newSections))
+	for _, s := range newSections {
+		seen[s.Name] = true
+		prev, ok := byName[s.Name]
+		if !ok {
+			ret = append(ret, Change{Section: s.Name, Kind: Added})
+			continue
+		}
+		ret = append(ret, sections(prev, s)...)
+	}
+	for _, s := range oldSections {
+		if !seen[s.Name] {
+			ret = append(ret, Change{Section: s.Name, Kind: Removed})
+		}
+	}
+	return ret
+}
+
+// ---------------------------------------- IMPL -------------------------------------
+
+func sections(old, new formatters.Section) []Change {
+	var ret []Change
+	if old.Title != new.Title {
+		// в заголовке некоторых разделов итоговое число доставок
+		ret = append(ret, Change{
+			Section: new.Name,
+			Kind:    Changed,
+			Columns: []ColumnChange{column("title", old.Title, new.Title)},
+		})
+	}
+	if strings.Join(old.Header, "\x00") != strings.Join(new.Header, "\x00") {
+		// другой состав столбцов (например, другой --group-by): строки несравнимы
+		return append(ret, Change{Section: new.Name, Kind: Changed, Columns: []ColumnChange{
+			column("columns", strings.Join(old.Header, ", "), strings.Join(new.Header, ", ")),
+		}})
+	}
+	keys := new.KeyColumns()
+	rowKey := func(row []string) string {
+		return strings.Join(row[:keys], "\x00")
+	}
+	oldRows := make(map[string][]string, len(old.Rows))
+	for _, row := range old.Rows {
+		oldRows[rowKey(row)] = row
+	}
+	var added, changed []Change
+	seen := make(map[string]bool, len(new.Rows))
+	for _, row := range new.Rows {
+		k := rowKey(row)
+		seen[k] = true
+		prev, ok := oldRows[k]
+		if !ok {
+			added = append(added, rowChange(new, keys, Added, nil, row))
+			continue
+		}
+		if c := rowChange(new, keys, Changed, prev, row); len(c.Columns) > 0 {
+			changed = append(changed, c)
+		}
+	}
+	var removed []Change
+	for _, row := range old.Rows {
+		if !seen[rowKey(row)] {
+			removed = append(removed, rowChange(old, keys, Removed, row, nil))
+		}
+	}
+	// порядок строк в разделе зависит от сортировки раздела; изменения выводятся по ключу
+	for _, cs := range [][]Change{removed, added, changed} {
+		sort.SliceStable(cs, func(i, j int) bool {
+			return strings.Join(cs[i].Key, "\x00") < strings.Join(cs[j].Key, "\x00")
+		})
+	}
+	ret = append(ret, changed...)
+	ret = append(ret, added...)
+	return append(ret, removed...)
+}
+
+func rowChange(s formatters.Section, keys int, kind Kind, old, new []string) Change {
+	ret := Change{Section: s.Name, Kind: kind}
+	row := new
+	if row == nil {
+		row = old
+	}
+	if keys > 0 {
+		ret.Key = append([]string(nil), row[:keys]...)
+	}
+	for i := keys; i < len(s.Header); i++ {
+		var o, n string
+		if old != nil {
+			o = old[i]
+		}
+		if new != nil {
+			n = new[i]
+		}
+		if kind == Changed && o == n {
+			continue
+		}
+		ret.Columns = append(ret.Columns, column(s.Header[i], o, n))
+	}
+	return ret
+}
+
+func column(name, old, new string) ColumnChange {
+	ret := ColumnChange{Column: name, Old: old, New: new}
+	if !formatters.IsMeasure(name) {
+		return ret
+	}
+	o, errO := strconv.ParseFloat(old, 64)
+	n, errN := strconv.ParseFloat(new, 64)
+	if errO == nil && errN == nil {
+		// значения в разделах выведены с ограниченной точностью; без округления 0.3333 - 0.1111
+		// дало бы 0.22219999999999998
+		d := math.Round((n-o)*1e6) / 1e6
+		ret.Delta = &d
+		if o != 0 {
+			pct := math.Round(d/o*1e4) / 100
+			ret.Percent = &pct
+		}
+	}
+	return ret
+}
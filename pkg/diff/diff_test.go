@@ -0,0 +1,119 @@
+package diff
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" //nolint:goimports
+	"sber-test/pkg/processors"
+	"sber-test/pkg/providers"
+	ts "sber-test/pkg/time-slot" //nolint:goimports
+)
+
+func report(t *testing.T, data providers.RecipeDeliveries, extra ...processors.RecipeReportSubj) processors.RecipeProcessorReport {
+	subjects := append([]processors.RecipeReportSubj{
+		processors.ReportUniqueRecipes(),
+		processors.ReportCounterPerRecipe(),
+		processors.ReportBusiestPostcode(),
+		processors.ReportIfMatchedRecipes("Potato"),
+	}, extra...)
+	ret, err := processors.NewRecipeReportProcessor(subjects[0], subjects[1:]...).Process(context.Background(), data)
+	assert.NoError(t, err)
+	return ret
+}
+
+var (
+	monday   = ts.ConstructDelivery(time.Monday, 10, 15)
+	thursday = ts.ConstructDelivery(time.Thursday, 10, 15)
+	before   = providers.RecipeDeliveries{
+		{Recipe: "Ink | Pen", Postcode: "1", Delivery: monday},
+		{Recipe: "B Potato", Postcode: "2", Delivery: monday},
+		{Recipe: "Old Potato", Postcode: "2", Delivery: thursday},
+	}
+	after = providers.RecipeDeliveries{
+		{Recipe: "Ink | Pen", Postcode: "1", Delivery: monday},
+		{Recipe: "Ink | Pen", Postcode: "1", Delivery: thursday},
+		{Recipe: "B Potato", Postcode: "2", Delivery: monday},
+		{Recipe: "New Potato", Postcode: "1", Delivery: monday},
+		{Recipe: "Veggie Potato", Postcode: "1", Delivery: monday},
+	}
+)
+
+func TestReports(t *testing.T) {
+	changes := Reports(report(t, before), report(t, after))
+	var buf bytes.Buffer
+	assert.NoError(t, WriteText(&buf, changes))
+	assert.Equal(t, `~ unique_recipe_count: unique_recipe_count 3 -> 4 (+1, +33.33%)
+~ count_per_recipe [Ink | Pen]: count 1 -> 2 (+1, +100%)
++ count_per_recipe [New Potato]: count 1
++ count_per_recipe [Veggie Potato]: count 1
+- count_per_recipe [Old Potato]: count 1
+~ busiest_postcode: postcode 2 -> 1
++ match_by_name [New Potato]
++ match_by_name [Veggie Potato]
+- match_by_name [Old Potato]
+`, buf.String())
+
+	assert.Empty(t, Reports(report(t, after), report(t, after)))
+	buf.Reset()
+	assert.NoError(t, WriteText(&buf, nil))
+	assert.Equal(t, "no changes\n", buf.String())
+}
+
+func TestReportsSections(t *testing.T) {
+	groupBy := func(by ...processors.Dimension) processors.RecipeReportSubj {
+		subj, err := processors.ReportGroupBy(processors.GroupBySpec{By: by})
+		assert.NoError(t, err)
+		return subj
+	}
+	old := report(t, before, groupBy(processors.DimPostcode))
+	changes := Reports(old, report(t, before, processors.ReportDeliveryCountForPostcodeAndTime("1", 0, 23)))
+	assert.Equal(t, []Change{
+		{Section: "count_per_postcode_and_time", Kind: Added},
+		{Section: "aggregations", Kind: Removed},
+	}, changes)
+
+	changes = Reports(old, report(t, before, groupBy(processors.DimRecipe)))
+	assert.Len(t, changes, 2)
+	assert.Equal(t, "title", changes[0].Columns[0].Column)
+	assert.Equal(t, ColumnChange{Column: "columns", Old: "postcode, count", New: "recipe, count"}, changes[1].Columns[0])
+
+	changes = Reports(report(t, before, groupBy(processors.DimPostcode, processors.DimWeekday)),
+		report(t, after, groupBy(processors.DimPostcode, processors.DimWeekday)))
+	var aggs []Change
+	for _, c := range changes {
+		if c.Section == "aggregations" {
+			aggs = append(aggs, c)
+		}
+	}
+	delta, pct := float64(2), float64(200)
+	assert.Equal(t, []Change{
+		{Section: "aggregations", Key: []string{"1", "Monday"}, Kind: Changed,
+			Columns: []ColumnChange{{Column: "count", Old: "1", New: "3", Delta: &delta, Percent: &pct}}},
+		{Section: "aggregations", Key: []string{"1", "Thursday"}, Kind: Added,
+			Columns: []ColumnChange{{Column: "count", New: "1"}}},
+		{Section: "aggregations", Key: []string{"2", "Thursday"}, Kind: Removed,
+			Columns: []ColumnChange{{Column: "count", Old: "1"}}},
+	}, aggs)
+}
+
+func TestWriteTable(t *testing.T) {
+	old := report(t, before, processors.ReportDeliveryCountForPostcodeAndTime("1", 9, 16))
+	var buf bytes.Buffer
+	assert.NoError(t, WriteTable(&buf, Reports(old, report(t, after))))
+	assert.Equal(t, `kind     section                      key            column               old  new  delta  percent
+----     -------                      ---            ------               ---  ---  -----  -------
+changed  unique_recipe_count                         unique_recipe_count  3    4    +1     +33.33%
+changed  count_per_recipe             Ink | Pen      count                1    2    +1     +100%
+added    count_per_recipe             New Potato     count                     1
+added    count_per_recipe             Veggie Potato  count                     1
+removed  count_per_recipe             Old Potato     count                1
+changed  busiest_postcode                            postcode             2    1
+added    match_by_name                New Potato
+added    match_by_name                Veggie Potato
+removed  match_by_name                Old Potato
+removed  count_per_postcode_and_time
+`, buf.String())
+}
@@ -0,0 +1,102 @@
+package diff
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+var kindSigns = map[Kind]string{Added: "+", Removed: "-", Changed: "~"}
+
+// WriteText изменения по одному в строке:
+//
+//	~ count_per_recipe [Tex-Mex Tilapia]: count 3 -> 5 (+2)
+//	+ match_by_name [Speedy Steak Fajitas]
+//	- aggregations
+func WriteText(w io.Writer, changes []Change) error {
+	bw := bufio.NewWriter(w)
+	if len(changes) == 0 {
+		_, _ = fmt.Fprintln(bw, "no changes")
+	}
+	for _, c := range changes {
+		_, _ = fmt.Fprintf(bw, "%s %s", kindSigns[c.Kind], c.Section)
+		if len(c.Key) > 0 {
+			_, _ = fmt.Fprintf(bw, " [%s]", strings.Join(c.Key, ", "))
+		}
+		for i, col := range c.Columns {
+			sep := ", "
+			if i == 0 {
+				sep = ": "
+			}
+			_, _ = fmt.Fprintf(bw, "%s%s %s", sep, col.Column, columnText(c.Kind, col))
+		}
+		_, _ = fmt.Fprintln(bw)
+	}
+	return bw.Flush()
+}
+
+func columnText(kind Kind, col ColumnChange) string {
+	switch kind {
+	case Added:
+		return col.New
+	case Removed:
+		return col.Old
+	}
+	ret := col.Old + " -> " + col.New
+	if col.Delta != nil {
+		ret += " (" + signed(*col.Delta)
+		if col.Percent != nil {
+			ret += ", " + signed(*col.Percent) + "%"
+		}
+		ret += ")"
+	}
+	return ret
+}
+
+// WriteTable изменения таблицей: строка на каждый изменившийся столбец
+func WriteTable(w io.Writer, changes []Change) error {
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "kind\tsection\tkey\tcolumn\told\tnew\tdelta\tpercent")
+	_, _ = fmt.Fprintln(tw, "----\t-------\t---\t------\t---\t---\t-----\t-------")
+	for _, c := range changes {
+		key := strings.Join(c.Key, ", ")
+		if len(c.Columns) == 0 {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t\t\t\t\t\n", c.Kind, c.Section, key)
+		}
+		for _, col := range c.Columns {
+			var delta, pct string
+			if col.Delta != nil {
+				delta = signed(*col.Delta)
+			}
+			if col.Percent != nil {
+				pct = signed(*col.Percent) + "%"
+			}
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				c.Kind, c.Section, key, col.Column, col.Old, col.New, delta, pct)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	// tabwriter дополняет пробелами и пустые последние столбцы
+	bw := bufio.NewWriter(w)
+	for _, line := range strings.SplitAfter(buf.String(), "\n") {
+		if len(line) > 0 {
+			_, _ = bw.WriteString(strings.TrimRight(line, " \n") + "\n")
+		}
+	}
+	return bw.Flush()
+}
+
+func signed(v float64) string {
+	s := strconv.FormatFloat(v, 'f', -1, 64)
+	if v > 0 {
+		s = "+" + s
+	}
+	return s
+}
@@ -0,0 +1,161 @@
+package formatters
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors" //nolint:goimports
+	"sber-test/internal/fsutil"
+	"sber-test/pkg/processors" //nolint:goimports
+)
+
+// FormatCSV ...
+const FormatCSV = "csv"
+
+func init() {
+	Register(FormatCSV, CSVFormatter{})
+}
+
+// csvHeaders заголовки разделов, в которых набор колонок зависит от режима подсчёта;
+// в CSV они выводятся всегда, чтобы заголовок файла не менялся от запуска к запуску
+var csvHeaders = map[string][]string{
+	"unique_recipe_count": {"unique_recipe_count", "error"},
+	"busiest_postcode":    {"postcode", "delivery_count", "error"},
+}
+
+// utf8BOM помогает Excel распознать кодировку файла
+const utf8BOM = "\uFEFF"
+
+// EscapeCSVCell защищает ячейку от выполнения как формулы в табличных редакторах (CSV injection):
+// значение, которое начинается с '=', '+', '-', '@', табуляции или '\r' и не является числом, предваряется апострофом
+func EscapeCSVCell(s string) string {
+	if len(s) == 0 || !strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return s
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return s
+	}
+	return "'" + s
+}
+
+// CSVFormatter выводит разделы отчёта в CSV, по одному разделу на файл
+type CSVFormatter struct {
+	// Section имя раздела (ключ в JSON); пусто - все разделы
+	Section string
+	// BOM писать UTF-8 BOM в начале файла
+	BOM bool
+}
+
+// Format пишет в w единственный раздел отчёта
+func (f CSVFormatter) Format(w io.Writer, report processors.RecipeProcessorReport) error {
+	const api = "CSVFormatter.Format"
+
+	sections, err := f.sections(report)
+	if err != nil {
+		return errors.Wrap(err, api)
+	}
+	if len(sections) != 1 {
+		names := make([]string, 0, len(sections))
+		for _, s := range sections {
+			names = append(names, s.Name)
+		}
+		return errors.Errorf("%s: report has %d sections (%s); select one section or write to directory",
+			api, len(sections), strings.Join(names, ", "))
+	}
+	return errors.Wrap(f.write(w, sections[0]), api)
+}
+
+// WriteFiles пишет каждый раздел отчёта в файл dir/<раздел>.csv и возвращает пути к файлам
+func (f CSVFormatter) WriteFiles(dir string, report processors.RecipeProcessorReport) ([]string, error) {
+	const api = "CSVFormatter.WriteFiles"
+
+	sections, err := f.sections(report)
+	if err != nil {
+		return nil, errors.Wrap(err, api)
+	}
+	if err = os.MkdirAll(dir, 0o750); err != nil {
+		return nil, errors.Wrapf(err, "%s: create dir('%s')", api, dir)
+	}
+	ret := make([]string, 0, len(sections))
+	for _, s := range sections {
+		path := filepath.Join(dir, s.Name+".csv")
+		if err = f.writeFile(path, s); err != nil {
+			return ret, errors.Wrapf(err, "%s: write file('%s')", api, path)
+		}
+		ret = append(ret, path)
+	}
+	return ret, nil
+}
+
+func (f CSVFormatter) sections(report processors.RecipeProcessorReport) ([]Section, error) {
+	all := Sections(report)
+	if len(f.Section) == 0 {
+		return all, nil
+	}
+	for _, s := range all {
+		if s.Name == f.Section {
+			return []Section{s}, nil
+		}
+	}
+	return nil, errors.Errorf("no section '%s' in report", f.Section)
+}
+
+func (f CSVFormatter) writeFile(path string, s Section) error {
+	return fsutil.WriteFileAtomic(path, func(w io.Writer) error {
+		return f.write(w, s)
+	})
+}
+
+func (f CSVFormatter) write(w io.Writer, s Section) error {
+	if f.BOM {
+		if _, err := io.WriteString(w, utf8BOM); err != nil {
+			return err
+		}
+	}
+	header, rows := s.Header, s.Rows
+	if stable, ok := csvHeaders[s.Name]; ok {
+		header, rows = stable, remapColumns(s, stable)
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(escapeCSVRow(header)); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := cw.Write(escapeCSVRow(row)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func escapeCSVRow(row []string) []string {
+	ret := make([]string, len(row))
+	for i, s := range row {
+		ret[i] = EscapeCSVCell(s)
+	}
+	return ret
+}
+
+// remapColumns раскладывает значения строк раздела по колонкам header; недостающие остаются пустыми
+func remapColumns(s Section, header []string) [][]string {
+	idx := make(map[string]int, len(s.Header))
+	for i, h := range s.Header {
+		idx[h] = i
+	}
+	ret := make([][]string, 0, len(s.Rows))
+	for _, row := range s.Rows {
+		r := make([]string, len(header))
+		for i, h := range header {
+			if j, ok := idx[h]; ok && j < len(row) {
+				r[i] = row[j]
+			}
+		}
+		ret = append(ret, r)
+	}
+	return ret
+}
@@ -0,0 +1,90 @@
+package formatters
+
+import (
+	"io"
+	"time"
+
+	"github.com/pkg/errors"    //nolint:goimports
+	"sber-test/pkg/processors" //nolint:goimports
+)
+
+// ReportSchemaVersion версия формата отчёта; меняется при любом изменении JSON Schema отчёта:
+// минорная - при добавлении полей, мажорная - при удалении или изменении смысла полей
+const ReportSchemaVersion = "2.1"
+
+type (
+	// ReportSource метаданные файла, по которому построен отчёт
+	ReportSource struct {
+		Path    string    `json:"path"`
+		Size    int64     `json:"size"`
+		ModTime time.Time `json:"mod_time"`
+		SHA256  string    `json:"sha256,omitempty"`
+	}
+
+	// EnvelopeMeta сведения о запуске, сохраняемые в конверте вместе с отчётом
+	EnvelopeMeta struct {
+		// GeneratedAt время построения отчёта; пусто - текущее время
+		GeneratedAt time.Time
+		Source      *ReportSource
+		// Params параметры командной строки, с которыми построен отчёт
+		Params map[string]string
+		// Args позиционные аргументы (например, запросы команды query)
+		Args []string
+	}
+
+	// ReportEnvelope версионированный конверт отчёта
+	ReportEnvelope struct {
+		SchemaVersion string                           `json:"schema_version"`
+		GeneratedAt   time.Time                        `json:"generated_at"`
+		Source        *ReportSource                    `json:"source,omitempty"`
+		Params        map[string]string                `json:"params,omitempty"`
+		Args          []string                         `json:"args,omitempty"`
+		Report        processors.RecipeProcessorReport `json:"report"`
+	}
+
+	// EnvelopeFormatter выводит отчёт внутри ReportEnvelope в одном из форматов json, pretty, yaml, toml
+	EnvelopeFormatter struct {
+		Encoding string
+		Meta     EnvelopeMeta
+	}
+)
+
+// NewReportEnvelope ...
+func NewReportEnvelope(report processors.RecipeProcessorReport, meta EnvelopeMeta) ReportEnvelope {
+	at := meta.GeneratedAt
+	if at.IsZero() {
+		at = time.Now()
+	}
+	return ReportEnvelope{
+		SchemaVersion: ReportSchemaVersion,
+		GeneratedAt:   at.UTC(),
+		Source:        meta.Source,
+		Params:        meta.Params,
+		Args:          meta.Args,
+		Report:        report,
+	}
+}
+
+var envelopeEncoders = map[string]func(io.Writer, interface{}) error{
+	FormatJSON:       encodeJSON,
+	FormatPrettyJSON: encodePrettyJSON,
+	FormatYAML:       encodeYAML,
+	FormatTOML:       encodeTOML,
+}
+
+// NewEnvelopeFormatter ...
+func NewEnvelopeFormatter(encoding string) (EnvelopeFormatter, error) {
+	if _, ok := envelopeEncoders[encoding]; !ok {
+		return EnvelopeFormatter{}, errors.Errorf("envelope is not supported by output format '%s'", encoding)
+	}
+	return EnvelopeFormatter{Encoding: encoding}, nil
+}
+
+// Format ...
+func (f EnvelopeFormatter) Format(w io.Writer, report processors.RecipeProcessorReport) error {
+	enc, ok := envelopeEncoders[f.Encoding]
+	if !ok {
+		return errors.Errorf("EnvelopeFormatter.Format: envelope is not supported by output format '%s'", f.Encoding)
+	}
+	return enc(w, NewReportEnvelope(report, f.Meta))
+}
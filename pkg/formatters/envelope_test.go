@@ -0,0 +1,114 @@
+package formatters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"github.com/stretchr/testify/assert" //nolint:goimports
+	"gopkg.in/yaml.v3"
+	"sber-test/pkg/models"
+	"sber-test/pkg/processors"
+	ts "sber-test/pkg/time-slot" //nolint:goimports
+)
+
+// fullReport отчёт, в котором заполнены все разделы
+func fullReport(t *testing.T) processors.RecipeProcessorReport {
+	report := richReport(t)
+	regions, err := processors.EnrichRegions(map[string]models.Region{"1": {Zone: "North"}}, processors.DimNone)
+	assert.NoError(t, err)
+	catalog, err := processors.JoinCatalog([]models.CatalogRecipe{{Name: "Ink | Pen", Category: "Office", Tags: []string{"blue"}}})
+	assert.NoError(t, err)
+	subjects := []processors.RecipeReportSubj{
+		processors.ReportCountPerCategory(), processors.ReportCountPerTag(), processors.ReportTagShare("blue"),
+		processors.ReportUniqueRecipesApprox(0.05), processors.ReportBusiestPostcodeApprox(0.05, 0),
+	}
+	extra, err := processors.NewRecipeReportProcessor(subjects[0], subjects[1:]...).
+		WithStages(processors.ValidatePostcodes(processors.PostcodeFormat(regexp.MustCompile(`^[12]$`))), regions, catalog).
+		Process(context.Background(), testData)
+	assert.NoError(t, err)
+	report.InvalidPostcodes = extra.InvalidPostcodes
+	report.UnmappedPostcodes = extra.UnmappedPostcodes
+	report.CountPerCategory = extra.CountPerCategory
+	report.CountPerTag = extra.CountPerTag
+	report.TagShares = extra.TagShares
+	report.UnknownRecipes = extra.UnknownRecipes
+	report.UniqueRecipeCountError = extra.UniqueRecipeCountError
+	report.BusiestPostcode.DeliveryCountError = extra.BusiestPostcode.DeliveryCountError
+	return report
+}
+
+func compileSchema(t *testing.T, schema map[string]interface{}) *jsonschema.Schema {
+	data, err := json.Marshal(schema)
+	assert.NoError(t, err)
+	c := jsonschema.NewCompiler()
+	assert.NoError(t, c.AddResource("schema.json", bytes.NewReader(data)))
+	s, err := c.Compile("schema.json")
+	assert.NoError(t, err)
+	return s
+}
+
+func TestReportSchema(t *testing.T) {
+	report := fullReport(t)
+	doc := viaJSON(t, report).(map[string]interface{})
+	props := ReportSchema()["properties"].(map[string]interface{})
+	for name := range props {
+		assert.Contains(t, doc, name, "section missing from full report")
+	}
+	assert.Len(t, doc, len(props))
+
+	s := compileSchema(t, ReportSchema())
+	assert.NoError(t, s.Validate(doc))
+	assert.NoError(t, s.Validate(viaJSON(t, processors.RecipeProcessorReport{})))
+
+	doc["count_per_recipe"] = []interface{}{map[string]interface{}{"recipe": "x"}}
+	assert.Error(t, s.Validate(doc))
+	doc["count_per_recipe"] = []interface{}{}
+	doc["unexpected"] = 1
+	assert.Error(t, s.Validate(doc))
+}
+
+func TestEnvelopeFormatter(t *testing.T) {
+	at := time.Date(2021, 3, 4, 5, 6, 7, 0, time.FixedZone("MSK", 3*60*60))
+	f, err := NewEnvelopeFormatter(FormatJSON)
+	assert.NoError(t, err)
+	f.Meta = EnvelopeMeta{
+		GeneratedAt: at,
+		Source:      &ReportSource{Path: "data.json", Size: 42, ModTime: at.UTC(), SHA256: "00ff"},
+		Params:      map[string]string{"count-per-recipe": "true"},
+		Args:        []string{"SELECT COUNT(*) FROM deliveries"},
+	}
+	var b bytes.Buffer
+	assert.NoError(t, f.Format(&b, fullReport(t)))
+	assert.True(t, strings.HasPrefix(b.String(),
+		`{"schema_version":"2.1","generated_at":"2021-03-04T02:06:07Z","source":{"path":"data.json",`), b.String())
+
+	var doc interface{}
+	assert.NoError(t, json.Unmarshal(b.Bytes(), &doc))
+	s := compileSchema(t, EnvelopeSchema())
+	assert.NoError(t, s.Validate(doc))
+	doc.(map[string]interface{})["schema_version"] = "0.9"
+	assert.Error(t, s.Validate(doc))
+
+	f.Encoding = FormatYAML
+	b.Reset()
+	assert.NoError(t, f.Format(&b, testReport(t)))
+	assert.NoError(t, yaml.Unmarshal(b.Bytes(), &doc))
+	assert.NoError(t, s.Validate(viaJSON(t, doc)))
+
+	_, err = NewEnvelopeFormatter(FormatTable)
+	assert.Error(t, err)
+}
+
+func TestSchemaOfHour(t *testing.T) {
+	re := regexp.MustCompile(schemaOf(reflect.TypeOf(ts.Hour(0)))["pattern"].(string))
+	for h := ts.Hour(0); h < 24; h++ {
+		assert.True(t, re.MatchString(h.String()), h.String())
+	}
+}
@@ -0,0 +1,59 @@
+package formatters
+
+import (
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"    //nolint:goimports
+	"sber-test/pkg/processors" //nolint:goimports
+)
+
+type (
+	// ReportFormatter выводит отчёт в определённом формате
+	ReportFormatter interface {
+		Format(io.Writer, processors.RecipeProcessorReport) error
+	}
+
+	// ReportFormatterFunc ...
+	ReportFormatterFunc func(io.Writer, processors.RecipeProcessorReport) error
+)
+
+// Format ...
+func (f ReportFormatterFunc) Format(w io.Writer, report processors.RecipeProcessorReport) error {
+	return f(w, report)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]ReportFormatter)
+)
+
+// Register регистрирует формат под именем name
+func Register(name string, f ReportFormatter) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = f
+}
+
+// Get формат по имени
+func Get(name string) (ReportFormatter, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	if f, ok := registry[name]; ok {
+		return f, nil
+	}
+	return nil, errors.Errorf("unknown output format '%s'", name)
+}
+
+// Names имена зарегистрированных форматов
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	ret := make([]string, 0, len(registry))
+	for name := range registry {
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+	return ret
+}
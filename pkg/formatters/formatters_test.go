@@ -0,0 +1,131 @@
+package formatters
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" //nolint:goimports
+	"sber-test/pkg/processors"
+	"sber-test/pkg/providers"
+	ts "sber-test/pkg/time-slot" //nolint:goimports
+)
+
+var testData = providers.RecipeDeliveries{
+	{Recipe: "Ink | Pen", Postcode: "1", Delivery: ts.ConstructDelivery(time.Monday, 10, 15)},
+	{Recipe: "Ink | Pen", Postcode: "1", Delivery: ts.ConstructDelivery(time.Thursday, 10, 15)},
+	{Recipe: "B Potato", Postcode: "2", Delivery: ts.ConstructDelivery(time.Wednesday, 8, 15)},
+	{Recipe: "Pen", Postcode: "3", Delivery: ts.ConstructDelivery(time.Wednesday, 8, 15)},
+}
+
+func testReport(t *testing.T) processors.RecipeProcessorReport {
+	data := testData[:3]
+	report, err := processors.NewRecipeReportProcessor(
+		processors.ReportCounterPerRecipe(),
+		processors.ReportBusiestPostcode(),
+		processors.ReportDeliveryCountForPostcodeAndTime("1", ts.Hour(9), ts.Hour(16)),
+	).Process(context.Background(), data)
+	assert.NoError(t, err)
+	return report
+}
+
+func format(t *testing.T, name string, report processors.RecipeProcessorReport) string {
+	f, err := Get(name)
+	assert.NoError(t, err)
+	var b bytes.Buffer
+	assert.NoError(t, f.Format(&b, report))
+	return b.String()
+}
+
+func TestSections(t *testing.T) {
+	sections := Sections(testReport(t))
+	var names []string
+	for _, s := range sections {
+		names = append(names, s.Name)
+		for _, row := range s.Rows {
+			assert.Len(t, row, len(s.Header), s.Name)
+		}
+	}
+	assert.Equal(t, []string{"count_per_recipe", "busiest_postcode", "count_per_postcode_and_time"}, names)
+	assert.Equal(t, [][]string{{"1", "9AM", "4PM", "2"}}, sections[2].Rows)
+}
+
+func TestFormatTable(t *testing.T) {
+	expected := `Count per recipe
+recipe     count
+------     -----
+B Potato   1
+Ink | Pen  2
+
+Busiest postcode
+postcode  delivery_count
+--------  --------------
+1         2
+
+Deliveries per postcode and time
+postcode  from  to   delivery_count
+--------  ----  --   --------------
+1         9AM   4PM  2
+`
+	assert.Equal(t, expected, format(t, FormatTable, testReport(t)))
+}
+
+func TestFormatMarkdown(t *testing.T) {
+	out := format(t, FormatMarkdown, testReport(t))
+	assert.Contains(t, out, "### Count per recipe\n\n| recipe | count |\n|---|---|\n| B Potato | 1 |\n| Ink \\| Pen | 2 |\n")
+	assert.Contains(t, out, "| 1 | 9AM | 4PM | 2 |\n")
+}
+
+func TestFormatJSON(t *testing.T) {
+	report := testReport(t)
+	assert.Contains(t, format(t, FormatJSON, report), `"busiest_postcode":{"postcode":"1","delivery_count":2}`)
+	assert.Contains(t, format(t, FormatPrettyJSON, report), "\n    \"busiest_postcode\": {\n")
+	_, err := Get("nope")
+	assert.Error(t, err)
+}
+
+func TestFormatCSV(t *testing.T) {
+	report := testReport(t)
+	_, err := Get(FormatCSV)
+	assert.NoError(t, err)
+
+	var b bytes.Buffer
+	assert.Error(t, CSVFormatter{}.Format(&b, report))
+	assert.Error(t, CSVFormatter{Section: "nope"}.Format(&b, report))
+
+	b.Reset()
+	assert.NoError(t, CSVFormatter{Section: "busiest_postcode", BOM: true}.Format(&b, report))
+	assert.Equal(t, "\uFEFFpostcode,delivery_count,error\n1,2,\n", b.String())
+
+	dir := t.TempDir()
+	files, err := CSVFormatter{}.WriteFiles(dir, report)
+	assert.NoError(t, err)
+	assert.Len(t, files, 3)
+	data, err := os.ReadFile(filepath.Join(dir, "count_per_recipe.csv"))
+	assert.NoError(t, err)
+	assert.Equal(t, "recipe,count\nB Potato,1\nInk | Pen,2\n", string(data))
+	data, err = os.ReadFile(filepath.Join(dir, "count_per_postcode_and_time.csv"))
+	assert.NoError(t, err)
+	assert.Equal(t, "postcode,from,to,delivery_count\n1,9AM,4PM,2\n", string(data))
+}
+
+func TestEscapeCSVCell(t *testing.T) {
+	for in, expected := range map[string]string{
+		"":                  "",
+		"Ink | Pen":         "Ink | Pen",
+		"=HYPERLINK(\"x\")": "'=HYPERLINK(\"x\")",
+		"+1+2":              "'+1+2",
+		"-cmd":              "'-cmd",
+		"@SUM(A1)":          "'@SUM(A1)",
+		"-12.5":             "-12.5",
+		"+4":                "+4",
+		"a=b":               "a=b",
+		"\t=1+2":            "'\t=1+2",
+		"\r=1+2":            "'\r=1+2",
+	} {
+		assert.Equal(t, expected, EscapeCSVCell(in), in)
+	}
+}
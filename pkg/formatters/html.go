@@ -0,0 +1,271 @@
+package formatters
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"sber-test/pkg/processors"
+	ts "sber-test/pkg/time-slot" //nolint:goimports
+)
+
+// FormatHTML ...
+const FormatHTML = "html"
+
+func init() {
+	Register(FormatHTML, ReportFormatterFunc(formatHTML))
+}
+
+// htmlTopN сколько столбцов показывать на столбчатых диаграммах
+const htmlTopN = 20
+
+type (
+	htmlChart struct {
+		Title string
+		SVG   template.HTML
+	}
+
+	htmlPage struct {
+		Charts   []htmlChart
+		Sections []Section
+	}
+)
+
+var htmlPageTemplate = template.Must(template.New(FormatHTML).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Recipe report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #222; }
+h1 { font-size: 1.6em; }
+h2 { font-size: 1.2em; margin-top: 2em; }
+table { border-collapse: collapse; margin-top: .5em; }
+th, td { border: 1px solid #ccc; padding: .25em .75em; text-align: left; }
+th { background: #f0f3f7; }
+tr:nth-child(even) td { background: #fafafa; }
+svg text { font-size: 12px; fill: #222; }
+</style>
+</head>
+<body>
+<h1>Recipe report</h1>
+{{- range .Charts}}
+<h2>{{.Title}}</h2>
+{{.SVG}}
+{{- end}}
+{{- range .Sections}}
+<h2 id="{{.Name}}">{{.Title}}</h2>
+<table>
+<thead><tr>{{range .Header}}<th>{{.}}</th>{{end}}</tr></thead>
+<tbody>
+{{- range .Rows}}
+<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+</body>
+</html>
+`))
+
+func formatHTML(w io.Writer, report processors.RecipeProcessorReport) error {
+	return htmlPageTemplate.Execute(w, htmlPage{
+		Charts:   htmlCharts(report),
+		Sections: Sections(report),
+	})
+}
+
+// ---------------------------------------- IMPL -------------------------------------
+
+type barItem struct {
+	label string
+	value int
+}
+
+func htmlCharts(report processors.RecipeProcessorReport) []htmlChart {
+	var ret []htmlChart
+	if bars := recipeBars(report); len(bars) > 0 {
+		ret = append(ret, htmlChart{Title: "Deliveries per recipe", SVG: barChartSVG(bars)})
+	}
+	if cells, ok := aggregationCounts(report, "weekday", "from"); ok {
+		ret = append(ret, htmlChart{Title: "Deliveries per weekday and hour", SVG: heatmapSVG(cells)})
+	}
+	if bars := postcodeBars(report); len(bars) > 0 {
+		ret = append(ret, htmlChart{Title: "Top postcodes", SVG: barChartSVG(bars)})
+	}
+	return ret
+}
+
+func recipeBars(report processors.RecipeProcessorReport) []barItem {
+	var bars []barItem
+	if report.CountPerRecipe != nil {
+		for _, c := range report.CountPerRecipe {
+			bars = append(bars, barItem{c.Recipe, c.Count})
+		}
+	} else if counts, ok := aggregationCounts(report, "recipe"); ok {
+		bars = barsOf(counts)
+	}
+	return topBars(bars)
+}
+
+func postcodeBars(report processors.RecipeProcessorReport) []barItem {
+	if counts, ok := aggregationCounts(report, "postcode"); ok {
+		return topBars(barsOf(counts))
+	}
+	if b := report.BusiestPostcode; b != nil {
+		return []barItem{{b.Postcode, b.DeliveryCount}}
+	}
+	return nil
+}
+
+// aggregationCounts суммы по значениям dims из первой группировки, содержащей все dims
+func aggregationCounts(report processors.RecipeProcessorReport, dims ...string) (map[string]int, bool) {
+	for _, agg := range report.Aggregations {
+		idx := make([]int, 0, len(dims))
+		for _, d := range dims {
+			for i, g := range agg.GroupBy {
+				if g == d {
+					idx = append(idx, i)
+					break
+				}
+			}
+		}
+		if len(idx) != len(dims) {
+			continue
+		}
+		ret := make(map[string]int)
+		for _, row := range agg.Rows {
+			key := make([]string, len(idx))
+			for i, j := range idx {
+				key[i] = row.Key[j]
+			}
+			ret[strings.Join(key, "\x00")] += row.Count
+		}
+		return ret, true
+	}
+	return nil, false
+}
+
+func barsOf(counts map[string]int) []barItem {
+	ret := make([]barItem, 0, len(counts))
+	for k, v := range counts {
+		ret = append(ret, barItem{k, v})
+	}
+	return ret
+}
+
+// topBars htmlTopN самых больших значений по убыванию
+func topBars(bars []barItem) []barItem {
+	sort.SliceStable(bars, func(i, j int) bool {
+		if bars[i].value != bars[j].value {
+			return bars[i].value > bars[j].value
+		}
+		return bars[i].label < bars[j].label
+	})
+	if len(bars) > htmlTopN {
+		bars = bars[:htmlTopN]
+	}
+	return bars
+}
+
+func barChartSVG(bars []barItem) template.HTML {
+	const (
+		labelW  = 220
+		barMaxW = 400
+		rowH    = 22
+		barH    = 16
+	)
+	maxV := 1
+	for _, b := range bars {
+		if b.value > maxV {
+			maxV = b.value
+		}
+	}
+	var s strings.Builder
+	_, _ = fmt.Fprintf(&s, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d">`,
+		labelW+barMaxW+60, rowH*len(bars)+4)
+	for i, b := range bars {
+		y := i*rowH + 2
+		w := b.value * barMaxW / maxV
+		label := html.EscapeString(b.label)
+		_, _ = fmt.Fprintf(&s, `<g><title>%s: %d</title>`, label, b.value)
+		_, _ = fmt.Fprintf(&s, `<text x="%d" y="%d" text-anchor="end">%s</text>`,
+			labelW-6, y+barH-3, html.EscapeString(truncate(b.label, 32)))
+		_, _ = fmt.Fprintf(&s, `<rect x="%d" y="%d" width="%d" height="%d" fill="#4e79a7"/>`, labelW, y, w, barH)
+		_, _ = fmt.Fprintf(&s, `<text x="%d" y="%d">%d</text></g>`, labelW+w+4, y+barH-3, b.value)
+	}
+	s.WriteString(`</svg>`)
+	return template.HTML(s.String()) //nolint:gosec // все значения экранированы выше
+}
+
+// heatmapSVG сетка день недели x час начала доставки; ключи cells - "<weekday>\x00<hour>"
+func heatmapSVG(cells map[string]int) template.HTML {
+	const (
+		labelW = 90
+		headH  = 20
+		cellW  = 34
+		cellH  = 22
+	)
+	weekdays := make(map[string]int, 7)
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		weekdays[d.String()] = (int(d) + 6) % 7 // неделя с понедельника
+	}
+	var grid [7][24]int
+	minH, maxH, maxV := 24, -1, 1
+	for k, v := range cells {
+		parts := strings.SplitN(k, "\x00", 2)
+		d, ok := weekdays[parts[0]]
+		var h ts.Hour
+		if !ok || len(parts) != 2 || h.FromString([]byte(parts[1])) != nil {
+			continue
+		}
+		grid[d][h] += v
+		if int(h) < minH {
+			minH = int(h)
+		}
+		if int(h) > maxH {
+			maxH = int(h)
+		}
+		if grid[d][h] > maxV {
+			maxV = grid[d][h]
+		}
+	}
+	if maxH < minH {
+		minH, maxH = 0, 23
+	}
+	var s strings.Builder
+	_, _ = fmt.Fprintf(&s, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d">`,
+		labelW+cellW*(maxH-minH+1)+2, headH+cellH*7+2)
+	for h := minH; h <= maxH; h++ {
+		_, _ = fmt.Fprintf(&s, `<text x="%d" y="%d" text-anchor="middle">%s</text>`,
+			labelW+(h-minH)*cellW+cellW/2, headH-6, ts.Hour(h))
+	}
+	for i := 0; i < 7; i++ {
+		wd := time.Weekday((i + 1) % 7)
+		y := headH + i*cellH
+		_, _ = fmt.Fprintf(&s, `<text x="%d" y="%d" text-anchor="end">%s</text>`, labelW-6, y+cellH-7, wd)
+		for h := minH; h <= maxH; h++ {
+			v := grid[i][h]
+			_, _ = fmt.Fprintf(&s,
+				`<rect x="%d" y="%d" width="%d" height="%d" fill="#4e79a7" fill-opacity="%s" stroke="#fff">`+
+					`<title>%s %s: %d</title></rect>`,
+				labelW+(h-minH)*cellW, y, cellW, cellH,
+				strconv.FormatFloat(0.05+0.95*float64(v)/float64(maxV), 'f', 2, 64), wd, ts.Hour(h), v)
+		}
+	}
+	s.WriteString(`</svg>`)
+	return template.HTML(s.String()) //nolint:gosec // метки строятся из дней недели и часов
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
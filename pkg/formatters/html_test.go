@@ -0,0 +1,47 @@
+package formatters
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert" //nolint:goimports
+	"sber-test/pkg/processors"
+	"sber-test/pkg/providers" //nolint:goimports
+)
+
+func TestFormatHTML(t *testing.T) {
+	byTime, err := processors.ReportGroupBy(processors.GroupBySpec{
+		By: []processors.Dimension{processors.DimWeekday, processors.DimFrom},
+	})
+	assert.NoError(t, err)
+	byPostcode, err := processors.ReportGroupBy(processors.GroupBySpec{
+		By: []processors.Dimension{processors.DimPostcode},
+	})
+	assert.NoError(t, err)
+	data := append(providers.RecipeDeliveries(nil), testData...)
+	data[3].Recipe = "<Pen>"
+	report, err := processors.NewRecipeReportProcessor(
+		processors.ReportCounterPerRecipe(), byTime, byPostcode,
+	).Process(context.Background(), data)
+	assert.NoError(t, err)
+
+	out := format(t, FormatHTML, report)
+	assert.True(t, strings.HasPrefix(out, "<!DOCTYPE html>"))
+	assert.NotContains(t, out, "<Pen>")
+	assert.Contains(t, out, "&lt;Pen&gt;")
+	assert.NotContains(t, out, "http-equiv")
+	assert.NotContains(t, out, "src=")
+	assert.Equal(t, 3, strings.Count(out, "<svg"))
+	for _, s := range []string{
+		"Deliveries per recipe", "Deliveries per weekday and hour", "Top postcodes",
+		`<h2 id="count_per_recipe">`, `<h2 id="aggregations_1">`, `<h2 id="aggregations_2">`,
+		"<title>Wednesday 8AM: 2</title>", "<title>Ink | Pen: 2</title>",
+	} {
+		assert.Contains(t, out, s)
+	}
+
+	out = format(t, FormatHTML, processors.RecipeProcessorReport{})
+	assert.NotContains(t, out, "<svg")
+	assert.NotContains(t, out, "<table>")
+}
@@ -0,0 +1,37 @@
+package formatters
+
+import (
+	"encoding/json"
+	"io"
+
+	"sber-test/pkg/processors"
+)
+
+// Formats
+const (
+	FormatJSON       = "json"
+	FormatPrettyJSON = "pretty"
+)
+
+func init() {
+	Register(FormatJSON, ReportFormatterFunc(formatJSON))
+	Register(FormatPrettyJSON, ReportFormatterFunc(formatPrettyJSON))
+}
+
+func formatJSON(w io.Writer, report processors.RecipeProcessorReport) error {
+	return encodeJSON(w, report)
+}
+
+func formatPrettyJSON(w io.Writer, report processors.RecipeProcessorReport) error {
+	return encodePrettyJSON(w, report)
+}
+
+func encodeJSON(w io.Writer, v interface{}) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+func encodePrettyJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	return enc.Encode(v)
+}
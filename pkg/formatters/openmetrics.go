@@ -0,0 +1,189 @@
+package formatters
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"sber-test/pkg/processors"
+)
+
+// FormatOpenMetrics ...
+const FormatOpenMetrics = "openmetrics"
+
+func init() {
+	Register(FormatOpenMetrics, ReportFormatterFunc(formatOpenMetrics))
+}
+
+// WritePrometheusText те же метрики, что и FormatOpenMetrics, в текстовом формате Prometheus (без # EOF,
+// TYPE и HELP счётчиков - для имени с _total), например для textfile collector'а node_exporter
+func WritePrometheusText(w io.Writer, report processors.RecipeProcessorReport) error {
+	return writeMetrics(w, report, false)
+}
+
+// ---------------------------------------- IMPL -------------------------------------
+
+type (
+	metricLabel struct {
+		name, value string
+	}
+
+	metricSample struct {
+		labels []metricLabel
+		value  string
+	}
+
+	// metricFamily метрика OpenMetrics; у счётчиков (counter) к имени сэмплов добавляется _total
+	metricFamily struct {
+		name, typ, help string
+		samples         []metricSample
+	}
+)
+
+func (f *metricFamily) add(value string, labels ...metricLabel) {
+	f.samples = append(f.samples, metricSample{labels: labels, value: value})
+}
+
+func (f *metricFamily) addInt(value int, labels ...metricLabel) {
+	f.add(strconv.Itoa(value), labels...)
+}
+
+func formatOpenMetrics(w io.Writer, report processors.RecipeProcessorReport) error {
+	return writeMetrics(w, report, true)
+}
+
+// writeMetrics в OpenMetrics TYPE и HELP относятся к имени метрики, а в текстовом формате Prometheus -
+// к имени сэмплов (у счётчика - с _total)
+func writeMetrics(w io.Writer, report processors.RecipeProcessorReport, openMetrics bool) error {
+	bw := bufio.NewWriter(w)
+	for _, f := range reportMetrics(report) {
+		if len(f.samples) == 0 {
+			continue
+		}
+		sample := f.name
+		if f.typ == "counter" {
+			sample += "_total"
+		}
+		family := sample
+		if openMetrics {
+			family = f.name
+		}
+		_, _ = fmt.Fprintf(bw, "# TYPE %s %s\n", family, f.typ)
+		_, _ = fmt.Fprintf(bw, "# HELP %s %s\n", family, f.help)
+		for _, s := range f.samples {
+			bw.WriteString(sample)
+			if len(s.labels) > 0 {
+				labels := make([]string, len(s.labels))
+				for i, l := range s.labels {
+					labels[i] = l.name + `="` + metricLabelEscaper.Replace(l.value) + `"`
+				}
+				_, _ = fmt.Fprintf(bw, "{%s}", strings.Join(labels, ","))
+			}
+			_, _ = fmt.Fprintf(bw, " %s\n", s.value)
+		}
+	}
+	if openMetrics {
+		bw.WriteString("# EOF\n")
+	}
+	return bw.Flush()
+}
+
+var metricLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func reportMetrics(report processors.RecipeProcessorReport) []*metricFamily {
+	uniq := &metricFamily{name: "unique_recipes", typ: "gauge", help: "Number of unique recipe names."}
+	if report.UniqueRecipeCount != nil {
+		uniq.addInt(*report.UniqueRecipeCount)
+	}
+	uniqErr := &metricFamily{name: "unique_recipes_error", typ: "gauge", help: "Error bound of approximate unique_recipes."}
+	if report.UniqueRecipeCountError != nil {
+		uniqErr.addInt(*report.UniqueRecipeCountError)
+	}
+	recipes := &metricFamily{name: "recipe_deliveries", typ: "counter", help: "Deliveries per recipe."}
+	for _, c := range report.CountPerRecipe {
+		recipes.addInt(c.Count, metricLabel{"recipe", c.Recipe})
+	}
+	postcodes := &metricFamily{name: "postcode_deliveries", typ: "counter", help: "Deliveries per postcode."}
+	if counts, ok := aggregationCounts(report, "postcode"); ok {
+		keys := make([]string, 0, len(counts))
+		for k := range counts {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			postcodes.addInt(counts[k], metricLabel{"postcode", k})
+		}
+	}
+	busiest := &metricFamily{
+		name: "busiest_postcode_deliveries", typ: "gauge", help: "Distinct delivery slots of the busiest postcode.",
+	}
+	busiestErr := &metricFamily{
+		name: "busiest_postcode_deliveries_error", typ: "gauge", help: "Error bound of approximate busiest_postcode_deliveries.",
+	}
+	if b := report.BusiestPostcode; b != nil {
+		busiest.addInt(b.DeliveryCount, metricLabel{"postcode", b.Postcode})
+		if b.DeliveryCountError != nil {
+			busiestErr.addInt(*b.DeliveryCountError, metricLabel{"postcode", b.Postcode})
+		}
+	}
+	postcodeTime := &metricFamily{
+		name: "postcode_time_deliveries", typ: "gauge", help: "Deliveries to postcode within time range.",
+	}
+	if c := report.CountPerPostcodeAndTime; c != nil {
+		postcodeTime.addInt(c.DeliveryCount,
+			metricLabel{"postcode", c.Postcode}, metricLabel{"from", c.From.String()}, metricLabel{"to", c.To.String()})
+	}
+	grouped := &metricFamily{name: "grouped_deliveries", typ: "counter", help: "Deliveries per group of --group-by."}
+	distinct := &metricFamily{
+		name: "grouped_distinct_values", typ: "gauge", help: "Distinct values of --count-distinct dimension per group.",
+	}
+	for _, agg := range report.Aggregations {
+		for _, row := range agg.Rows {
+			labels := []metricLabel{{"group_by", strings.Join(agg.GroupBy, ",")}}
+			for i, d := range agg.GroupBy {
+				labels = append(labels, metricLabel{d, row.Key[i]})
+			}
+			grouped.addInt(row.Count, labels...)
+			if row.Distinct != nil {
+				distinct.addInt(*row.Distinct, append(labels, metricLabel{"count_distinct", agg.CountDistinct})...)
+			}
+		}
+	}
+	invalid := &metricFamily{
+		name: "invalid_postcode_deliveries", typ: "counter", help: "Deliveries skipped because of invalid postcode.",
+	}
+	if inv := report.InvalidPostcodes; inv != nil {
+		invalid.addInt(inv.Count)
+	}
+	unmapped := &metricFamily{
+		name: "unmapped_postcode_deliveries", typ: "counter", help: "Deliveries to postcodes without region.",
+	}
+	if un := report.UnmappedPostcodes; un != nil {
+		unmapped.addInt(un.Count)
+	}
+	categories := &metricFamily{name: "category_deliveries", typ: "counter", help: "Deliveries per recipe category."}
+	for _, c := range report.CountPerCategory {
+		categories.addInt(c.Count, metricLabel{"category", c.Category})
+	}
+	tags := &metricFamily{name: "tag_deliveries", typ: "counter", help: "Deliveries per recipe tag."}
+	for _, c := range report.CountPerTag {
+		tags.addInt(c.Count, metricLabel{"tag", c.Tag})
+	}
+	shares := &metricFamily{name: "tag_share", typ: "gauge", help: "Share of deliveries with recipe tag."}
+	for _, t := range report.TagShares {
+		shares.add(strconv.FormatFloat(t.Share, 'g', -1, 64), metricLabel{"tag", t.Tag})
+	}
+	unknown := &metricFamily{
+		name: "unknown_recipe_deliveries", typ: "counter", help: "Deliveries of recipes missing from catalog.",
+	}
+	if un := report.UnknownRecipes; un != nil {
+		unknown.addInt(un.Count)
+	}
+	return []*metricFamily{
+		uniq, uniqErr, recipes, postcodes, busiest, busiestErr, postcodeTime, grouped, distinct,
+		invalid, unmapped, categories, tags, shares, unknown,
+	}
+}
@@ -0,0 +1,62 @@
+package formatters
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert" //nolint:goimports
+	"sber-test/pkg/processors"
+	"sber-test/pkg/providers" //nolint:goimports
+)
+
+func TestFormatOpenMetrics(t *testing.T) {
+	byPostcode, err := processors.ReportGroupBy(processors.GroupBySpec{
+		By: []processors.Dimension{processors.DimPostcode},
+	})
+	assert.NoError(t, err)
+	data := append(providers.RecipeDeliveries(nil), testData...)
+	data[3].Recipe = `Pen "Gel"`
+	report, err := processors.NewRecipeReportProcessor(
+		processors.ReportUniqueRecipes(), processors.ReportCounterPerRecipe(), byPostcode,
+	).Process(context.Background(), data)
+	assert.NoError(t, err)
+
+	expected := `# TYPE unique_recipes gauge
+# HELP unique_recipes Number of unique recipe names.
+unique_recipes 3
+# TYPE recipe_deliveries counter
+# HELP recipe_deliveries Deliveries per recipe.
+recipe_deliveries_total{recipe="B Potato"} 1
+recipe_deliveries_total{recipe="Ink | Pen"} 2
+recipe_deliveries_total{recipe="Pen \"Gel\""} 1
+# TYPE postcode_deliveries counter
+# HELP postcode_deliveries Deliveries per postcode.
+postcode_deliveries_total{postcode="1"} 2
+postcode_deliveries_total{postcode="2"} 1
+postcode_deliveries_total{postcode="3"} 1
+# TYPE grouped_deliveries counter
+# HELP grouped_deliveries Deliveries per group of --group-by.
+grouped_deliveries_total{group_by="postcode",postcode="1"} 2
+grouped_deliveries_total{group_by="postcode",postcode="2"} 1
+grouped_deliveries_total{group_by="postcode",postcode="3"} 1
+# EOF
+`
+	assert.Equal(t, expected, format(t, FormatOpenMetrics, report))
+	assert.Equal(t, "# EOF\n", format(t, FormatOpenMetrics, processors.RecipeProcessorReport{}))
+}
+
+func TestWritePrometheusText(t *testing.T) {
+	report, err := processors.NewRecipeReportProcessor(processors.ReportUniqueRecipes(), processors.ReportCounterPerRecipe()).
+		Process(context.Background(), append(providers.RecipeDeliveries(nil), testData[:2]...))
+	assert.NoError(t, err)
+	var buf bytes.Buffer
+	assert.NoError(t, WritePrometheusText(&buf, report))
+	assert.Equal(t, `# TYPE unique_recipes gauge
+# HELP unique_recipes Number of unique recipe names.
+unique_recipes 1
+# TYPE recipe_deliveries_total counter
+# HELP recipe_deliveries_total Deliveries per recipe.
+recipe_deliveries_total{recipe="Ink | Pen"} 2
+`, buf.String())
+}
@@ -0,0 +1,141 @@
+package formatters
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"sber-test/pkg/processors"
+	ts "sber-test/pkg/time-slot" //nolint:goimports
+)
+
+// JSONSchemaDialect ...
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// ReportSchema JSON Schema отчёта RecipeProcessorReport (без конверта)
+func ReportSchema() map[string]interface{} {
+	s := schemaOf(reflect.TypeOf(processors.RecipeProcessorReport{}))
+	s["$schema"] = JSONSchemaDialect
+	s["$id"] = "urn:sber-test:recipe-report:" + ReportSchemaVersion
+	s["title"] = "Recipe report"
+	return s
+}
+
+// EnvelopeSchema JSON Schema отчёта в конверте ReportEnvelope
+func EnvelopeSchema() map[string]interface{} {
+	s := schemaOf(reflect.TypeOf(ReportEnvelope{}))
+	s["$schema"] = JSONSchemaDialect
+	s["$id"] = "urn:sber-test:recipe-report-envelope:" + ReportSchemaVersion
+	s["title"] = "Recipe report envelope"
+	props := s["properties"].(map[string]interface{})
+	props["schema_version"].(map[string]interface{})["const"] = ReportSchemaVersion
+	return s
+}
+
+// ---------------------------------------- IMPL -------------------------------------
+
+// schemaOverrides типы со своим JSON-представлением (json.Marshaler); RecipeProcessorReport
+// тоже json.Marshaler, но сохраняет имена и типы полей
+var schemaOverrides = map[reflect.Type]func() map[string]interface{}{
+	reflect.TypeOf(time.Time{}): func() map[string]interface{} {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	},
+	reflect.TypeOf(ts.Hour(0)): func() map[string]interface{} {
+		return map[string]interface{}{"type": "string", "pattern": "^(1[0-2]|[1-9])(AM|PM)$"}
+	},
+	reflect.TypeOf(ts.Delivery{}): func() map[string]interface{} {
+		return map[string]interface{}{"type": "string"}
+	},
+}
+
+// schemaOf схема значения типа t в том виде, в каком его выводит encoding/json
+func schemaOf(t reflect.Type) map[string]interface{} {
+	if o, ok := schemaOverrides[t]; ok {
+		return o()
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemaOf(t.Elem())
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": schemaOf(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": schemaOf(t.Elem())}
+	case reflect.Struct:
+		return structSchema(t)
+	}
+	return map[string]interface{}{}
+}
+
+func structSchema(t reflect.Type) map[string]interface{} {
+	props := make(map[string]interface{})
+	var required []string
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag := f.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name, opts := tag, ""
+			if i := strings.IndexByte(tag, ','); i >= 0 {
+				name, opts = tag[:i], tag[i:]
+			}
+			ft := f.Type
+			if f.Anonymous && len(name) == 0 {
+				if ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
+				}
+				if ft.Kind() == reflect.Struct {
+					walk(ft) // поля встроенной структуры encoding/json поднимает на уровень выше
+					continue
+				}
+			}
+			if len(f.PkgPath) > 0 {
+				continue
+			}
+			if len(name) == 0 {
+				name = f.Name
+			}
+			s := schemaOf(ft)
+			if strings.Contains(opts, ",omitempty") {
+				props[name] = s
+				continue
+			}
+			switch ft.Kind() {
+			case reflect.Ptr, reflect.Slice, reflect.Map:
+				s = nullable(s)
+			}
+			props[name] = s
+			required = append(required, name)
+		}
+	}
+	walk(t)
+	ret := map[string]interface{}{
+		"type":                 "object",
+		"properties":           props,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		ret["required"] = required
+	}
+	return ret
+}
+
+// nullable nil-значения указателей, срезов и map без omitempty выводятся как null
+func nullable(s map[string]interface{}) map[string]interface{} {
+	if typ, ok := s["type"].(string); ok {
+		s["type"] = []string{typ, "null"}
+		return s
+	}
+	return map[string]interface{}{"anyOf": []interface{}{s, map[string]interface{}{"type": "null"}}}
+}
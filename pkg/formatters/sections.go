@@ -0,0 +1,198 @@
+package formatters
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"sber-test/pkg/processors"
+)
+
+// Section раздел отчёта в виде таблицы; Name совпадает с ключом раздела в JSON
+type Section struct {
+	Name   string
+	Title  string
+	Header []string
+	Rows   [][]string
+	// Truncated в разделе не все строки (--group-by с --limit)
+	Truncated bool
+}
+
+// singleRowSections разделы из одной строки без ключа
+var singleRowSections = map[string]bool{
+	"unique_recipe_count":         true,
+	"busiest_postcode":            true,
+	"count_per_postcode_and_time": true,
+}
+
+// measureColumns числовые столбцы разделов
+var measureColumns = map[string]bool{
+	"unique_recipe_count": true,
+	"error":               true,
+	"count":               true,
+	"delivery_count":      true,
+	"recipes":             true,
+	"total_count":         true,
+	"share":               true,
+}
+
+// IsMeasure числовой столбец (счётчик, доля, погрешность), а не измерение
+func IsMeasure(column string) bool {
+	return measureColumns[column] || strings.HasPrefix(column, "distinct_")
+}
+
+// KeyColumns число ведущих столбцов, однозначно определяющих строку раздела (например, "recipe name"
+// или измерения --group-by): столбцы до первого числового; 0 - раздел из одной строки
+func (s Section) KeyColumns() int {
+	if singleRowSections[s.Name] {
+		return 0
+	}
+	for i, h := range s.Header {
+		if IsMeasure(h) || h == "variants" {
+			return i
+		}
+	}
+	return len(s.Header)
+}
+
+// Sections разделы отчёта в порядке полей RecipeProcessorReport; отсутствующие в отчёте разделы пропускаются
+func Sections(report processors.RecipeProcessorReport) []Section {
+	var ret []Section
+	if report.UniqueRecipeCount != nil {
+		s := Section{
+			Name:   "unique_recipe_count",
+			Title:  "Unique recipe count",
+			Header: []string{"unique_recipe_count"},
+			Rows:   [][]string{{strconv.Itoa(*report.UniqueRecipeCount)}},
+		}
+		if report.UniqueRecipeCountError != nil {
+			s.Header = append(s.Header, "error")
+			s.Rows[0] = append(s.Rows[0], strconv.Itoa(*report.UniqueRecipeCountError))
+		}
+		ret = append(ret, s)
+	}
+	if report.CountPerRecipe != nil {
+		s := Section{Name: "count_per_recipe", Title: "Count per recipe", Header: []string{"recipe", "count"}}
+		for _, c := range report.CountPerRecipe {
+			s.Rows = append(s.Rows, []string{c.Recipe, strconv.Itoa(c.Count)})
+		}
+		ret = append(ret, s)
+	}
+	if b := report.BusiestPostcode; b != nil {
+		s := Section{
+			Name:   "busiest_postcode",
+			Title:  "Busiest postcode",
+			Header: []string{"postcode", "delivery_count"},
+			Rows:   [][]string{{b.Postcode, strconv.Itoa(b.DeliveryCount)}},
+		}
+		if b.DeliveryCountError != nil {
+			s.Header = append(s.Header, "error")
+			s.Rows[0] = append(s.Rows[0], strconv.Itoa(*b.DeliveryCountError))
+		}
+		ret = append(ret, s)
+	}
+	if c := report.CountPerPostcodeAndTime; c != nil {
+		ret = append(ret, Section{
+			Name:   "count_per_postcode_and_time",
+			Title:  "Deliveries per postcode and time",
+			Header: []string{"postcode", "from", "to", "delivery_count"},
+			Rows:   [][]string{{c.Postcode, c.From.String(), c.To.String(), strconv.Itoa(c.DeliveryCount)}},
+		})
+	}
+	if report.RecipesMatchedByName != nil {
+		s := Section{Name: "match_by_name", Title: "Recipes matched by name", Header: []string{"recipe"}}
+		for _, r := range report.RecipesMatchedByName {
+			s.Rows = append(s.Rows, []string{r})
+		}
+		ret = append(ret, s)
+	}
+	for i, agg := range report.Aggregations {
+		s := Section{
+			Name:      "aggregations",
+			Title:     "Count by " + strings.Join(agg.GroupBy, ", "),
+			Header:    append(append([]string(nil), agg.GroupBy...), "count"),
+			Truncated: agg.Truncated,
+		}
+		if len(report.Aggregations) > 1 {
+			s.Name = fmt.Sprintf("aggregations_%d", i+1)
+		}
+		if len(agg.GroupBy) == 0 {
+			s.Title = "Count"
+		}
+		if len(agg.CountDistinct) > 0 {
+			s.Header = append(s.Header, "distinct_"+agg.CountDistinct)
+		}
+		for _, row := range agg.Rows {
+			r := append(append([]string(nil), row.Key...), strconv.Itoa(row.Count))
+			if row.Distinct != nil {
+				r = append(r, strconv.Itoa(*row.Distinct))
+			}
+			s.Rows = append(s.Rows, r)
+		}
+		ret = append(ret, s)
+	}
+	if report.MergedRecipes != nil {
+		s := Section{Name: "merged_recipes", Title: "Merged recipe variants", Header: []string{"recipe", "variants"}}
+		for _, m := range report.MergedRecipes {
+			s.Rows = append(s.Rows, []string{m.Recipe, strings.Join(m.Variants, "; ")})
+		}
+		ret = append(ret, s)
+	}
+	if inv := report.InvalidPostcodes; inv != nil {
+		s := Section{
+			Name:   "invalid_postcodes",
+			Title:  fmt.Sprintf("Invalid postcodes (%d deliveries skipped)", inv.Count),
+			Header: []string{"postcode", "reason", "count"},
+		}
+		for _, p := range inv.Postcodes {
+			s.Rows = append(s.Rows, []string{p.Postcode, p.Reason, strconv.Itoa(p.Count)})
+		}
+		ret = append(ret, s)
+	}
+	if un := report.UnmappedPostcodes; un != nil {
+		s := Section{
+			Name:   "unmapped_postcodes",
+			Title:  fmt.Sprintf("Postcodes without region (%d deliveries)", un.Count),
+			Header: []string{"postcode", "count"},
+		}
+		for _, p := range un.Postcodes {
+			s.Rows = append(s.Rows, []string{p.Postcode, strconv.Itoa(p.Count)})
+		}
+		ret = append(ret, s)
+	}
+	if report.CountPerCategory != nil {
+		s := Section{Name: "count_per_category", Title: "Count per category", Header: []string{"category", "count", "recipes"}}
+		for _, c := range report.CountPerCategory {
+			s.Rows = append(s.Rows, []string{c.Category, strconv.Itoa(c.Count), strconv.Itoa(c.Recipes)})
+		}
+		ret = append(ret, s)
+	}
+	if report.CountPerTag != nil {
+		s := Section{Name: "count_per_tag", Title: "Count per tag", Header: []string{"tag", "count"}}
+		for _, c := range report.CountPerTag {
+			s.Rows = append(s.Rows, []string{c.Tag, strconv.Itoa(c.Count)})
+		}
+		ret = append(ret, s)
+	}
+	if report.TagShares != nil {
+		s := Section{Name: "tag_share", Title: "Tag share", Header: []string{"tag", "delivery_count", "total_count", "share"}}
+		for _, t := range report.TagShares {
+			s.Rows = append(s.Rows, []string{
+				t.Tag, strconv.Itoa(t.DeliveryCount), strconv.Itoa(t.TotalCount), strconv.FormatFloat(t.Share, 'f', 4, 64),
+			})
+		}
+		ret = append(ret, s)
+	}
+	if un := report.UnknownRecipes; un != nil {
+		s := Section{
+			Name:   "unknown_recipes",
+			Title:  fmt.Sprintf("Recipes missing from catalog (%d deliveries)", un.Count),
+			Header: []string{"recipe", "count"},
+		}
+		for _, r := range un.Recipes {
+			s.Rows = append(s.Rows, []string{r.Recipe, strconv.Itoa(r.Count)})
+		}
+		ret = append(ret, s)
+	}
+	return ret
+}
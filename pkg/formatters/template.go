@@ -0,0 +1,191 @@
+package formatters
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors" //nolint:goimports
+	"sber-test/pkg/processors"
+	ts "sber-test/pkg/time-slot" //nolint:goimports
+)
+
+// TemplateFuncs функции, доступные в шаблонах отчёта:
+//
+//	sortBy "Field" list, sortByDesc "Field" list - копия списка, отсортированная по полю
+//	limit n list - первые n элементов списка
+//	percent part total, percent fraction - процент вида "12.5%"
+//	hour h - час в форме ts.Hour.String(), например "9AM"
+//	join sep list, upper s, lower s
+var TemplateFuncs = template.FuncMap{
+	"sortBy":     func(field string, list interface{}) (interface{}, error) { return sortBy(field, list, false) },
+	"sortByDesc": func(field string, list interface{}) (interface{}, error) { return sortBy(field, list, true) },
+	"limit":      limit,
+	"percent":    percent,
+	"hour":       hour,
+	"join":       func(sep string, list []string) string { return strings.Join(list, sep) },
+	"upper":      strings.ToUpper,
+	"lower":      strings.ToLower,
+}
+
+// NewTemplateFormatter формат, выводящий RecipeProcessorReport через text/template
+func NewTemplateFormatter(name, text string) (ReportFormatter, error) {
+	const api = "NewTemplateFormatter"
+
+	t, err := template.New(name).Funcs(TemplateFuncs).Parse(text)
+	if err != nil {
+		return nil, errors.Wrap(err, api)
+	}
+	return ReportFormatterFunc(func(w io.Writer, report processors.RecipeProcessorReport) error {
+		return t.Execute(w, report)
+	}), nil
+}
+
+// LoadTemplateFormatter NewTemplateFormatter из файла
+func LoadTemplateFormatter(f string) (ReportFormatter, error) {
+	const api = "LoadTemplateFormatter"
+
+	data, err := os.ReadFile(f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s: read file('%s')", api, f)
+	}
+	return NewTemplateFormatter(filepath.Base(f), string(data))
+}
+
+// ---------------------------------------- IMPL -------------------------------------
+
+func sortBy(field string, list interface{}, desc bool) (interface{}, error) {
+	v := reflect.ValueOf(list)
+	if v.Kind() != reflect.Slice {
+		return nil, errors.Errorf("sortBy: %T is not a list", list)
+	}
+	et := v.Type().Elem()
+	if et.Kind() == reflect.Ptr {
+		et = et.Elem()
+	}
+	if et.Kind() != reflect.Struct {
+		return nil, errors.Errorf("sortBy: %s is not a struct", et)
+	}
+	sf, ok := et.FieldByName(field)
+	if !ok {
+		return nil, errors.Errorf("sortBy: %s has no field '%s'", et, field)
+	}
+	ft := sf.Type
+	if ft.Kind() == reflect.Ptr {
+		ft = ft.Elem()
+	}
+	if _, err := compareValues(reflect.Zero(ft), reflect.Zero(ft)); err != nil {
+		return nil, err
+	}
+	ret := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+	reflect.Copy(ret, v)
+	key := func(i int) reflect.Value {
+		item := reflect.Indirect(ret.Index(i))
+		if !item.IsValid() {
+			return item
+		}
+		return reflect.Indirect(item.FieldByIndex(sf.Index))
+	}
+	sort.SliceStable(ret.Interface(), func(i, j int) bool {
+		c, _ := compareValues(key(i), key(j))
+		if desc {
+			return c > 0
+		}
+		return c < 0
+	})
+	return ret.Interface(), nil
+}
+
+// compareValues сравнивает строки и числа; отсутствующее значение (nil) меньше любого другого
+func compareValues(l, r reflect.Value) (int, error) {
+	if !l.IsValid() || !r.IsValid() {
+		return sign(float64(boolToInt(l.IsValid()) - boolToInt(r.IsValid()))), nil
+	}
+	switch l.Kind() {
+	case reflect.String:
+		return strings.Compare(l.String(), r.String()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return sign(float64(l.Int()) - float64(r.Int())), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return sign(float64(l.Uint()) - float64(r.Uint())), nil
+	case reflect.Float32, reflect.Float64:
+		return sign(l.Float() - r.Float()), nil
+	}
+	return 0, errors.Errorf("sortBy: can't compare %s", l.Type())
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func sign(f float64) int {
+	switch {
+	case f < 0:
+		return -1
+	case f > 0:
+		return 1
+	}
+	return 0
+}
+
+func limit(n int, list interface{}) (interface{}, error) {
+	v := reflect.ValueOf(list)
+	if v.Kind() != reflect.Slice {
+		return nil, errors.Errorf("limit: %T is not a list", list)
+	}
+	if n >= 0 && n < v.Len() {
+		v = v.Slice(0, n)
+	}
+	return v.Interface(), nil
+}
+
+func toFloat(x interface{}) (float64, error) {
+	v := reflect.Indirect(reflect.ValueOf(x))
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	}
+	return 0, errors.Errorf("%T is not a number", x)
+}
+
+func percent(x interface{}, total ...interface{}) (string, error) {
+	f, err := toFloat(x)
+	if err != nil {
+		return "", errors.Wrap(err, "percent")
+	}
+	switch len(total) {
+	case 0:
+	case 1:
+		var t float64
+		if t, err = toFloat(total[0]); err != nil {
+			return "", errors.Wrap(err, "percent")
+		}
+		if t == 0 {
+			return "n/a", nil
+		}
+		f /= t
+	default:
+		return "", errors.New("percent: too many arguments")
+	}
+	return fmt.Sprintf("%.1f%%", f*100), nil
+}
+
+func hour(x interface{}) (string, error) {
+	f, err := toFloat(x)
+	if err != nil || f < 0 || f >= 24 {
+		return "", errors.Errorf("hour: bad value %v", x)
+	}
+	return ts.Hour(f).String(), nil
+}
@@ -0,0 +1,38 @@
+package formatters
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateFormatter(t *testing.T) {
+	const text = `{{range sortByDesc "Count" .CountPerRecipe}}{{.Recipe}}={{.Count}};{{end}}
+{{range limit 1 (sortBy "Recipe" .CountPerRecipe)}}{{upper .Recipe}}{{end}}
+{{with .CountPerPostcodeAndTime}}{{.Postcode}} {{hour .From}}-{{.To}} {{percent .DeliveryCount 3}}{{end}}
+{{percent 0.25}} {{hour 13}}`
+	f, err := NewTemplateFormatter("test", text)
+	assert.NoError(t, err)
+	var b bytes.Buffer
+	assert.NoError(t, f.Format(&b, testReport(t)))
+	assert.Equal(t, "Ink | Pen=2;B Potato=1;\nB POTATO\n1 9AM-4PM 66.7%\n25.0% 1PM", b.String())
+}
+
+func TestTemplateFormatterErrors(t *testing.T) {
+	_, err := NewTemplateFormatter("bad", "{{.Nope")
+	assert.Error(t, err)
+	for _, text := range []string{
+		`{{sortBy "Nope" .CountPerRecipe}}`,
+		`{{sortBy "Count" .BusiestPostcode}}`,
+		`{{hour 24}}`,
+		`{{percent "x"}}`,
+	} {
+		f, err := NewTemplateFormatter("bad", text)
+		assert.NoError(t, err)
+		var b bytes.Buffer
+		assert.Error(t, f.Format(&b, testReport(t)), text)
+	}
+	_, err = LoadTemplateFormatter("/nonexistent/report.tmpl")
+	assert.Error(t, err)
+}
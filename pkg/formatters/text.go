@@ -0,0 +1,76 @@
+package formatters
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"sber-test/pkg/processors"
+)
+
+// Formats
+const (
+	FormatTable    = "table"
+	FormatMarkdown = "markdown"
+)
+
+func init() {
+	Register(FormatTable, ReportFormatterFunc(formatTable))
+	Register(FormatMarkdown, ReportFormatterFunc(formatMarkdown))
+}
+
+func formatTable(w io.Writer, report processors.RecipeProcessorReport) error {
+	bw := bufio.NewWriter(w)
+	for i, s := range Sections(report) {
+		if i > 0 {
+			_, _ = fmt.Fprintln(bw)
+		}
+		_, _ = fmt.Fprintf(bw, "%s\n", s.Title)
+		tw := tabwriter.NewWriter(bw, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, strings.Join(s.Header, "\t"))
+		dashes := make([]string, len(s.Header))
+		for j, h := range s.Header {
+			dashes[j] = strings.Repeat("-", len([]rune(h)))
+		}
+		_, _ = fmt.Fprintln(tw, strings.Join(dashes, "\t"))
+		for _, row := range s.Rows {
+			_, _ = fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		if e := tw.Flush(); e != nil {
+			return e
+		}
+	}
+	return bw.Flush()
+}
+
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\n", " ")
+
+func formatMarkdown(w io.Writer, report processors.RecipeProcessorReport) error {
+	bw := bufio.NewWriter(w)
+	for i, s := range Sections(report) {
+		if i > 0 {
+			_, _ = fmt.Fprintln(bw)
+		}
+		_, _ = fmt.Fprintf(bw, "### %s\n\n", markdownEscaper.Replace(s.Title))
+		writeMarkdownRow(bw, s.Header)
+		sep := make([]string, len(s.Header))
+		for j := range sep {
+			sep[j] = "---"
+		}
+		_, _ = fmt.Fprintf(bw, "|%s|\n", strings.Join(sep, "|"))
+		for _, row := range s.Rows {
+			writeMarkdownRow(bw, row)
+		}
+	}
+	return bw.Flush()
+}
+
+func writeMarkdownRow(w io.Writer, cells []string) {
+	escaped := make([]string, len(cells))
+	for i, c := range cells {
+		escaped[i] = markdownEscaper.Replace(c)
+	}
+	_, _ = fmt.Fprintf(w, "| %s |\n", strings.Join(escaped, " | "))
+}
@@ -0,0 +1,39 @@
+package formatters
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/BurntSushi/toml" //nolint:goimports
+	"sber-test/pkg/processors"   //nolint:goimports
+)
+
+// FormatTOML ...
+const FormatTOML = "toml"
+
+func init() {
+	Register(FormatTOML, ReportFormatterFunc(formatTOML))
+}
+
+func formatTOML(w io.Writer, report processors.RecipeProcessorReport) error {
+	return encodeTOML(w, report)
+}
+
+// encodeTOML кодирует JSON-представление v, чтобы поля и значения совпадали с JSON;
+// ключи таблиц выводятся по алфавиту, поля со значением null пропускаются
+func encodeTOML(w io.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var tree map[string]interface{}
+	if err = dec.Decode(&tree); err != nil {
+		return err
+	}
+	enc := toml.NewEncoder(w)
+	enc.Indent = ""
+	return enc.Encode(tree)
+}
@@ -0,0 +1,32 @@
+package formatters
+
+import (
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
+// reportTree отчёт (или конверт с отчётом) в виде дерева, построенного из его JSON-представления:
+// имена полей, порядок и строковые формы ts.Hour/ts.Delivery совпадают с JSON
+func reportTree(v interface{}) (*yaml.Node, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	// JSON - подмножество YAML, а yaml.Node сохраняет порядок ключей
+	var doc yaml.Node
+	if err = yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	root := doc.Content[0]
+	resetStyle(root)
+	return root, nil
+}
+
+// resetStyle сбрасывает flow-стиль и кавычки, унаследованные от JSON
+func resetStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		resetStyle(c)
+	}
+}
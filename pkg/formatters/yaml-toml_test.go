@@ -0,0 +1,65 @@
+package formatters
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/stretchr/testify/assert" //nolint:goimports
+	"gopkg.in/yaml.v3"
+	"sber-test/pkg/processors" //nolint:goimports
+)
+
+// viaJSON приводит значение к виду, который дал бы json.Unmarshal, чтобы сравнивать деревья разных форматов
+func viaJSON(t *testing.T, v interface{}) interface{} {
+	data, err := json.Marshal(v)
+	assert.NoError(t, err)
+	var ret interface{}
+	assert.NoError(t, json.Unmarshal(data, &ret))
+	return ret
+}
+
+func richReport(t *testing.T) processors.RecipeProcessorReport {
+	report := testReport(t)
+	agg, err := processors.ReportGroupBy(processors.GroupBySpec{
+		By:            []processors.Dimension{processors.DimPostcode, processors.DimWeekday},
+		CountDistinct: processors.DimRecipe,
+	})
+	assert.NoError(t, err)
+	subjects := []processors.RecipeReportSubj{
+		agg, processors.ReportUniqueRecipes(), processors.ReportIfMatchedRecipes("Ink"),
+	}
+	extra, err := processors.NewRecipeReportProcessor(subjects[0], subjects[1:]...).
+		WithStages(processors.NormalizeRecipes(map[string]string{"Pen": "Ink | Pen"})).
+		Process(context.Background(), testData)
+	assert.NoError(t, err)
+	report.Aggregations = extra.Aggregations
+	report.UniqueRecipeCount = extra.UniqueRecipeCount
+	report.RecipesMatchedByName = extra.RecipesMatchedByName
+	report.MergedRecipes = extra.MergedRecipes
+	return report
+}
+
+func TestFormatYAMLRoundTrip(t *testing.T) {
+	report := richReport(t)
+	out := format(t, FormatYAML, report)
+	assert.Contains(t, out, "busiest_postcode:\n  postcode: \"1\"\n  delivery_count: 2\n")
+	assert.Contains(t, out, "  from: 9AM\n")
+
+	var decoded interface{}
+	assert.NoError(t, yaml.Unmarshal([]byte(out), &decoded))
+	assert.Equal(t, viaJSON(t, report), viaJSON(t, decoded))
+}
+
+func TestFormatTOMLRoundTrip(t *testing.T) {
+	report := richReport(t)
+	out := format(t, FormatTOML, report)
+	assert.Contains(t, out, "[busiest_postcode]\ndelivery_count = 2\npostcode = \"1\"\n")
+	assert.Contains(t, out, "[[aggregations.rows]]\n")
+
+	var decoded map[string]interface{}
+	_, err := toml.Decode(out, &decoded)
+	assert.NoError(t, err, out)
+	assert.Equal(t, viaJSON(t, report), viaJSON(t, decoded))
+}
@@ -0,0 +1,32 @@
+package formatters
+
+import (
+	"io"
+
+	"gopkg.in/yaml.v3"         //nolint:goimports
+	"sber-test/pkg/processors" //nolint:goimports
+)
+
+// FormatYAML ...
+const FormatYAML = "yaml"
+
+func init() {
+	Register(FormatYAML, ReportFormatterFunc(formatYAML))
+}
+
+func formatYAML(w io.Writer, report processors.RecipeProcessorReport) error {
+	return encodeYAML(w, report)
+}
+
+func encodeYAML(w io.Writer, v interface{}) error {
+	root, err := reportTree(v)
+	if err != nil {
+		return err
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err = enc.Encode(root); err != nil {
+		return err
+	}
+	return enc.Close()
+}
@@ -0,0 +1,357 @@
+// Package generate синтетические доставки models.RecipeDelivery для нагрузочных тестов и демонстраций
+package generate
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors" //nolint:goimports
+	"sber-test/pkg/models"
+	ts "sber-test/pkg/time-slot" //nolint:goimports
+)
+
+// Distribution распределение выбора рецептов и postcode
+type Distribution string
+
+// distributions
+const (
+	Uniform Distribution = "uniform"
+	// Zipf k-й по популярности рецепт/postcode встречается в ~k^s раз реже первого
+	Zipf Distribution = "zipf"
+)
+
+// defaults
+const (
+	DefaultCount        = 1000
+	DefaultRecipes      = 20
+	DefaultPostcodes    = 20
+	DefaultPostcodeBase = 10100
+	DefaultZipfS        = 1.1
+)
+
+type (
+	// Window окно доставки с весом
+	Window struct {
+		From, To ts.Hour
+		Weight   float64
+	}
+
+	// Options параметры генерации; нулевые значения заменяются значениями по умолчанию
+	Options struct {
+		Count int
+		Seed  int64
+		// Recipes словарь рецептов; по умолчанию RecipeNames(DefaultRecipes)
+		Recipes    []string
+		RecipeDist Distribution
+		// Postcodes число разных postcode: PostcodeBase, PostcodeBase+1, ..
+		Postcodes int
+		// PostcodeBase первый postcode; 0 - допустимое значение, поэтому по умолчанию (nil) DefaultPostcodeBase
+		PostcodeBase *int
+		PostcodeDist Distribution
+		// ZipfS показатель распределения Zipf, больше 1
+		ZipfS float64
+		// Weekdays веса дней недели по индексу time.Weekday; все нулевые - равномерно
+		Weekdays [7]float64
+		// Windows окна доставки; пусто - случайные окна from < to
+		Windows []Window
+	}
+
+	// Generator провайдер Count синтетических доставок; при каждом Provide выдаёт одни и те же
+	// доставки для одного и того же Seed
+	Generator struct {
+		opts      Options
+		weekdays  []float64
+		windows   []float64
+		postcodes []string
+	}
+)
+
+// ParseDistribution ...
+func ParseDistribution(s string) (Distribution, error) {
+	switch d := Distribution(s); d {
+	case Uniform, Zipf:
+		return d, nil
+	}
+	return "", errors.Errorf("unknown distribution '%s'; use %s or %s", s, Uniform, Zipf)
+}
+
+// ParseWeekdays веса дней недели "Mon=3,Sat=1,Sun": дни - полные или сокращённые английские
+// названия без учёта регистра, вес по умолчанию 1, неперечисленные дни не выбираются; хотя бы один
+// вес должен быть больше 0
+func ParseWeekdays(s string) ([7]float64, error) {
+	var ret [7]float64
+	for _, item := range strings.Split(s, ",") {
+		name, weight, err := parseWeighted(item)
+		if err != nil {
+			return ret, err
+		}
+		wd, ok := weekdayByName(name)
+		if !ok {
+			return ret, errors.Errorf("unknown weekday '%s'", name)
+		}
+		ret[wd] = weight
+	}
+	if ret == ([7]float64{}) {
+		return ret, errors.New("all weights are zero")
+	}
+	return ret, nil
+}
+
+// ParseWindows окна доставки с весами "10AM-3PM=3,9AM-5PM": вес по умолчанию 1
+func ParseWindows(s string) ([]Window, error) {
+	var ret []Window
+	for _, item := range strings.Split(s, ",") {
+		spec, weight, err := parseWeighted(item)
+		if err != nil {
+			return nil, err
+		}
+		from, to, ok := strings.Cut(spec, "-")
+		w := Window{Weight: weight}
+		if !ok {
+			return nil, errors.Errorf("window '%s' is not 'from-to'", spec)
+		}
+		if err = w.From.FromString([]byte(strings.TrimSpace(from))); err == nil {
+			err = w.To.FromString([]byte(strings.TrimSpace(to)))
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "window '%s'", spec)
+		}
+		if w.From >= w.To {
+			return nil, errors.Errorf("window '%s': from is not before to", spec)
+		}
+		ret = append(ret, w)
+	}
+	return ret, nil
+}
+
+// RecipeNames n названий рецептов: сочетания блюд и способов приготовления
+func RecipeNames(n int) []string {
+	ret := make([]string, 0, n)
+	for i := 0; len(ret) < n; i++ {
+		dish := dishes[i%len(dishes)]
+		round := i / len(dishes)
+		switch {
+		case round == 0:
+			ret = append(ret, dish)
+		case round <= len(styles):
+			ret = append(ret, styles[round-1]+" "+dish)
+		default:
+			ret = append(ret, fmt.Sprintf("%s %d", dish, round-len(styles)+1))
+		}
+	}
+	return ret
+}
+
+// LoadRecipeNames названия рецептов из текстового файла: по одному на строке, пустые строки пропускаются
+func LoadRecipeNames(f string) ([]string, error) {
+	const api = "LoadRecipeNames"
+
+	file, err := os.Open(f)
+	if err != nil {
+		return nil, errors.Wrap(err, api)
+	}
+	defer file.Close() //nolint:gosec
+	var ret []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if name := strings.TrimSpace(scanner.Text()); len(name) > 0 {
+			ret = append(ret, name)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, api)
+	}
+	if len(ret) == 0 {
+		return nil, errors.Errorf("%s: no recipes in '%s'", api, f)
+	}
+	return ret, nil
+}
+
+// New ...
+func New(opts Options) (*Generator, error) {
+	const api = "generate.New"
+
+	opts = opts.withDefaults()
+	switch {
+	case opts.Count < 0:
+		return nil, errors.Errorf("%s: negative count", api)
+	case opts.Postcodes < 0:
+		return nil, errors.Errorf("%s: negative postcode count", api)
+	case *opts.PostcodeBase < 0:
+		return nil, errors.Errorf("%s: negative postcode base", api)
+	case opts.ZipfS <= 1:
+		return nil, errors.Errorf("%s: zipf exponent must be greater than 1", api)
+	}
+	for _, d := range []Distribution{opts.RecipeDist, opts.PostcodeDist} {
+		if _, err := ParseDistribution(string(d)); err != nil {
+			return nil, errors.Wrap(err, api)
+		}
+	}
+	g := &Generator{opts: opts, postcodes: make([]string, opts.Postcodes)}
+	for i := range g.postcodes {
+		g.postcodes[i] = strconv.Itoa(*opts.PostcodeBase + i)
+	}
+	var err error
+	if g.weekdays, err = cumulative(opts.Weekdays[:]); err != nil {
+		return nil, errors.Wrapf(err, "%s: weekdays", api)
+	}
+	if len(opts.Windows) > 0 {
+		weights := make([]float64, len(opts.Windows))
+		for i, w := range opts.Windows {
+			weights[i] = w.Weight
+		}
+		if g.windows, err = cumulative(weights); err != nil {
+			return nil, errors.Wrapf(err, "%s: windows", api)
+		}
+	}
+	return g, nil
+}
+
+// Options параметры с подставленными значениями по умолчанию
+func (g *Generator) Options() Options {
+	return g.opts
+}
+
+// Provide ...
+func (g *Generator) Provide(ctx context.Context, consumer func(models.RecipeDelivery) error) error {
+	r := rand.New(rand.NewSource(g.opts.Seed)) //nolint:gosec
+	recipe := g.chooser(r, g.opts.RecipeDist, len(g.opts.Recipes))
+	postcode := g.chooser(r, g.opts.PostcodeDist, len(g.postcodes))
+	for i := 0; i < g.opts.Count; i++ {
+		if i%1024 == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		d := models.RecipeDelivery{
+			Recipe:   g.opts.Recipes[recipe()],
+			Postcode: g.postcodes[postcode()],
+			Delivery: ts.Delivery{WDay: ts.Weekday(weighted(r, g.weekdays))},
+		}
+		if len(g.windows) > 0 {
+			w := g.opts.Windows[weighted(r, g.windows)]
+			d.Delivery.From, d.Delivery.To = w.From, w.To
+		} else {
+			from := r.Intn(23)
+			d.Delivery.From, d.Delivery.To = ts.Hour(from), ts.Hour(from+1+r.Intn(23-from))
+		}
+		if err := consumer(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ---------------------------------------- IMPL -------------------------------------
+
+var dishes = []string{
+	"Chicken Sausage Pizzas", "Korean-Style Chicken Thighs", "Melty Monterey Jack Burgers",
+	"Mole-Spiced Beef Tacos", "Tex-Mex Tilapia", "Cherry Balsamic Pork Chops", "Creamy Dill Chicken",
+	"Speedy Steak Fajitas", "Hot Honey Chicken", "Garlic Herb Butter Steak", "Cajun Shrimp Linguine",
+	"Mushroom Risotto", "Veggie Fajita Bowls", "Potato Gnocchi", "Sweet Chili Salmon",
+	"Black Bean Burritos", "Lemon Pepper Cod", "Teriyaki Meatballs", "Caprese Chicken", "Pesto Penne",
+}
+
+var styles = []string{"Spicy", "Crispy", "Smoky", "Cheesy", "One-Pan", "Easy"}
+
+func (o Options) withDefaults() Options {
+	if o.Count == 0 {
+		o.Count = DefaultCount
+	}
+	if len(o.Recipes) == 0 {
+		o.Recipes = RecipeNames(DefaultRecipes)
+	}
+	if len(o.RecipeDist) == 0 {
+		o.RecipeDist = Uniform
+	}
+	if o.Postcodes == 0 {
+		o.Postcodes = DefaultPostcodes
+	}
+	if o.PostcodeBase == nil {
+		base := DefaultPostcodeBase
+		o.PostcodeBase = &base
+	}
+	if len(o.PostcodeDist) == 0 {
+		o.PostcodeDist = Uniform
+	}
+	if o.ZipfS == 0 {
+		o.ZipfS = DefaultZipfS
+	}
+	if o.Weekdays == ([7]float64{}) {
+		for i := range o.Weekdays {
+			o.Weekdays[i] = 1
+		}
+	}
+	return o
+}
+
+// chooser выбор индекса из [0, n)
+func (g *Generator) chooser(r *rand.Rand, d Distribution, n int) func() int {
+	if d == Zipf && n > 1 {
+		z := rand.NewZipf(r, g.opts.ZipfS, 1, uint64(n-1))
+		return func() int {
+			return int(z.Uint64())
+		}
+	}
+	return func() int {
+		return r.Intn(n)
+	}
+}
+
+// cumulative накопленные веса для weighted
+func cumulative(weights []float64) ([]float64, error) {
+	ret := make([]float64, len(weights))
+	var sum float64
+	for i, w := range weights {
+		if w < 0 {
+			return nil, errors.New("negative weight")
+		}
+		sum += w
+		ret[i] = sum
+	}
+	if sum == 0 {
+		return nil, errors.New("all weights are zero")
+	}
+	return ret, nil
+}
+
+// weighted индекс, выбранный с вероятностью, пропорциональной весу
+func weighted(r *rand.Rand, cumulative []float64) int {
+	x := r.Float64() * cumulative[len(cumulative)-1]
+	// элементы с нулевым весом равны предыдущему и не выбираются
+	return sort.Search(len(cumulative), func(i int) bool {
+		return cumulative[i] > x
+	})
+}
+
+func parseWeighted(item string) (string, float64, error) {
+	name, w, ok := strings.Cut(strings.TrimSpace(item), "=")
+	name = strings.TrimSpace(name)
+	if len(name) == 0 {
+		return "", 0, errors.Errorf("empty item in '%s'", item)
+	}
+	if !ok {
+		return name, 1, nil
+	}
+	weight, err := strconv.ParseFloat(strings.TrimSpace(w), 64)
+	if err != nil || weight < 0 {
+		return "", 0, errors.Errorf("'%s' has wrong weight", item)
+	}
+	return name, weight, nil
+}
+
+func weekdayByName(name string) (time.Weekday, bool) {
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		if s := wd.String(); strings.EqualFold(s, name) || strings.EqualFold(s[:3], name) {
+			return wd, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,160 @@
+package generate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" //nolint:goimports
+	"sber-test/pkg/models"
+	"sber-test/pkg/providers"
+	ts "sber-test/pkg/time-slot" //nolint:goimports
+)
+
+func collect(t *testing.T, opts Options) providers.RecipeDeliveries {
+	g, err := New(opts)
+	assert.NoError(t, err)
+	ret, err := providers.Collect(context.Background(), g)
+	assert.NoError(t, err)
+	return ret
+}
+
+func TestGenerator(t *testing.T) {
+	opts := Options{Count: 2000, Seed: 7, Recipes: []string{"A", "B", "C"}, Postcodes: 5}
+	data := collect(t, opts)
+	assert.Len(t, data, 2000)
+	assert.Equal(t, data, collect(t, opts), "same seed, same data")
+	assert.NotEqual(t, data, collect(t, Options{Count: 2000, Seed: 8, Recipes: []string{"A", "B", "C"}, Postcodes: 5}))
+
+	recipes, postcodes, weekdays := map[string]int{}, map[string]int{}, map[time.Weekday]int{}
+	for _, d := range data {
+		recipes[d.Recipe]++
+		postcodes[d.Postcode]++
+		weekdays[d.Delivery.WDay]++
+		assert.True(t, d.Delivery.From < d.Delivery.To && d.Delivery.To <= 23, d.Delivery)
+	}
+	assert.Len(t, recipes, 3)
+	assert.Len(t, weekdays, 7)
+	assert.Equal(t, []string{"10100", "10101", "10102", "10103", "10104"}, keys(postcodes))
+	for _, c := range recipes {
+		assert.InDelta(t, 2000/3, c, 120)
+	}
+
+	base := 0
+	postcodes = map[string]int{}
+	for _, d := range collect(t, Options{Count: 100, Seed: 7, Postcodes: 3, PostcodeBase: &base}) {
+		postcodes[d.Postcode]++
+	}
+	assert.Equal(t, []string{"0", "1", "2"}, keys(postcodes))
+}
+
+func TestGeneratorDistributions(t *testing.T) {
+	weekdays, err := ParseWeekdays("mon=3, Saturday")
+	assert.NoError(t, err)
+	windows, err := ParseWindows("10AM-3PM=3,9-17")
+	assert.NoError(t, err)
+	assert.Equal(t, []Window{{From: 10, To: 15, Weight: 3}, {From: 9, To: 17, Weight: 1}}, windows)
+	data := collect(t, Options{
+		Count: 4000, Seed: 1, Recipes: RecipeNames(50), RecipeDist: Zipf, Postcodes: 100, PostcodeDist: Zipf, ZipfS: 1.5,
+		Weekdays: weekdays, Windows: windows,
+	})
+	recipes, postcodes := map[string]int{}, map[string]int{}
+	var monday, wide int
+	for _, d := range data {
+		recipes[d.Recipe]++
+		postcodes[d.Postcode]++
+		assert.Contains(t, []time.Weekday{time.Monday, time.Saturday}, d.Delivery.WDay)
+		if d.Delivery.WDay == time.Monday {
+			monday++
+		}
+		if d.Delivery == ts.ConstructDelivery(d.Delivery.WDay, 9, 17) {
+			wide++
+		} else {
+			assert.Equal(t, ts.ConstructDelivery(d.Delivery.WDay, 10, 15), d.Delivery)
+		}
+	}
+	assert.InDelta(t, 3000, monday, 150)
+	assert.InDelta(t, 1000, wide, 150)
+	// первый по рангу рецепт и postcode встречаются намного чаще последних
+	assert.Greater(t, recipes[RecipeNames(1)[0]], 10*recipes[RecipeNames(50)[49]]+1)
+	assert.Greater(t, postcodes["10100"], 1000)
+	assert.Less(t, postcodes["10199"], 20)
+}
+
+func TestParse(t *testing.T) {
+	_, err := ParseDistribution("normal")
+	assert.Error(t, err)
+	_, err = ParseWeekdays("Mon,Funday")
+	assert.Error(t, err)
+	_, err = ParseWeekdays("Mon=-1")
+	assert.Error(t, err)
+	_, err = ParseWeekdays("Mon=0,Tue=0")
+	assert.EqualError(t, err, "all weights are zero")
+	for _, s := range []string{"3PM-10AM", "10AM", "10AM-25", ","} {
+		_, err = ParseWindows(s)
+		assert.Error(t, err, s)
+	}
+	_, err = New(Options{Weekdays: [7]float64{}, Windows: []Window{{From: 1, To: 2}}})
+	assert.Error(t, err, "zero window weights")
+	_, err = New(Options{RecipeDist: "normal"})
+	assert.Error(t, err)
+	_, err = New(Options{ZipfS: 0.5})
+	assert.Error(t, err)
+
+	names := RecipeNames(45)
+	assert.Equal(t, "Chicken Sausage Pizzas", names[0])
+	assert.Equal(t, "Spicy Chicken Sausage Pizzas", names[20])
+	assert.Equal(t, "Crispy Korean-Style Chicken Thighs", names[41])
+	assert.Equal(t, "Chicken Sausage Pizzas 2", RecipeNames(141)[140])
+}
+
+func TestWrite(t *testing.T) {
+	data := collect(t, Options{Count: 3, Seed: 3})
+	for _, c := range []struct {
+		format Format
+		check  func(string)
+	}{
+		{FormatJSON, func(s string) {
+			var got []models.RecipeDelivery
+			assert.NoError(t, json.Unmarshal([]byte(s), &got))
+			assert.Equal(t, []models.RecipeDelivery(data), got)
+			assert.Equal(t, 5, strings.Count(s, "\n"))
+		}},
+		{FormatNDJSON, func(s string) {
+			lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+			assert.Len(t, lines, 3)
+			var got models.RecipeDelivery
+			assert.NoError(t, json.Unmarshal([]byte(lines[2]), &got))
+			assert.Equal(t, data[2], got)
+		}},
+		{FormatCSV, func(s string) {
+			lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+			assert.Equal(t, "postcode,recipe,delivery", lines[0])
+			assert.Equal(t, data[0].Postcode+","+data[0].Recipe+","+data[0].Delivery.String(), lines[1])
+		}},
+	} {
+		var buf bytes.Buffer
+		n, err := Write(context.Background(), &buf, c.format, data)
+		assert.NoError(t, err, c.format)
+		assert.Equal(t, 3, n)
+		c.check(buf.String())
+	}
+
+	var buf bytes.Buffer
+	_, err := Write(context.Background(), &buf, FormatJSON, providers.RecipeDeliveries{})
+	assert.NoError(t, err)
+	assert.Equal(t, "[\n]\n", buf.String())
+}
+
+func keys(m map[string]int) []string {
+	ret := make([]string, 0, len(m))
+	for k := range m {
+		ret = append(ret, k)
+	}
+	sort.Strings(ret)
+	return ret
+}
@@ -0,0 +1,101 @@
+package generate
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors" //nolint:goimports
+	"sber-test/pkg/models"
+	"sber-test/pkg/providers" //nolint:goimports
+)
+
+// Format формат записи доставок
+type Format string
+
+// formats
+const (
+	// FormatJSON массив JSON, как во входных файлах --source
+	FormatJSON Format = "json"
+	// FormatNDJSON объект JSON на строке
+	FormatNDJSON Format = "ndjson"
+	// FormatCSV столбцы postcode,recipe,delivery; delivery - в том же виде, что и в JSON
+	FormatCSV Format = "csv"
+)
+
+// ParseFormat ...
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case FormatJSON, FormatNDJSON, FormatCSV:
+		return f, nil
+	}
+	return "", errors.Errorf("unknown format '%s'; use %s, %s or %s", s, FormatJSON, FormatNDJSON, FormatCSV)
+}
+
+// Write записывает доставки provider в w по мере чтения, не собирая их в памяти; возвращает
+// число записанных доставок
+func Write(ctx context.Context, w io.Writer, format Format, provider providers.RecipeDeliveryProvider) (int, error) {
+	const api = "generate.Write"
+
+	bw := bufio.NewWriter(w)
+	var n int
+	var write func(models.RecipeDelivery) error
+	var finish func() error
+	switch format {
+	case FormatJSON, FormatNDJSON:
+		// массив JSON - по элементу на строке
+		sep := "\n"
+		if format == FormatJSON {
+			sep = ",\n"
+			_, _ = bw.WriteString("[\n")
+		}
+		write = func(d models.RecipeDelivery) error {
+			data, err := json.Marshal(d)
+			if err != nil {
+				return err
+			}
+			if n > 0 {
+				_, _ = bw.WriteString(sep)
+			}
+			_, err = bw.Write(data)
+			return err
+		}
+		finish = func() error {
+			if n > 0 {
+				_, _ = bw.WriteString("\n")
+			}
+			if format == FormatJSON {
+				_, _ = bw.WriteString("]\n")
+			}
+			return nil
+		}
+	case FormatCSV:
+		cw := csv.NewWriter(bw)
+		_ = cw.Write([]string{"postcode", "recipe", "delivery"})
+		write = func(d models.RecipeDelivery) error {
+			return cw.Write([]string{d.Postcode, d.Recipe, d.Delivery.String()})
+		}
+		finish = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+	default:
+		return 0, errors.Errorf("%s: unknown format '%s'", api, format)
+	}
+	err := provider.Provide(ctx, func(d models.RecipeDelivery) error {
+		if err := write(d); err != nil {
+			return err
+		}
+		n++
+		return nil
+	})
+	if err == nil {
+		err = finish()
+	}
+	if err == nil {
+		err = bw.Flush()
+	}
+	return n, errors.Wrap(err, api)
+}
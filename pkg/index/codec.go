@@ -0,0 +1,323 @@
+package index
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"hash"
+	"hash/crc32"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"      //nolint:goimports
+	ts "sber-test/pkg/time-slot" //nolint:goimports
+)
+
+// Формат файла индекса: Magic, версия формата, словари "recipe name" и "postcode", окна доставки,
+// записи (номера в словарях), posting lists (разности соседних номеров), окна доставки по "postcode"
+// и CRC-32 всего предыдущего; целые числа - uvarint
+const (
+	// Magic начало файла индекса
+	Magic = "SBTIDX"
+	// Version версия формата
+	Version = 1
+)
+
+// ErrNotIndex файл не является индексом
+var ErrNotIndex = errors.New("not an index file")
+
+// WriteTo ...
+func (idx *Index) WriteTo(w io.Writer) (int64, error) {
+	const api = "Index.WriteTo"
+
+	enc := newEncoder(w)
+	enc.bytes([]byte(Magic))
+	enc.uint(Version)
+	enc.strings(idx.recipes)
+	enc.strings(idx.postcodes)
+	enc.uint(uint64(len(idx.slots)))
+	for _, slot := range idx.slots {
+		enc.bytes([]byte{byte(slot.WDay), byte(slot.From), byte(slot.To)})
+	}
+	enc.uint(uint64(len(idx.records)))
+	for _, rec := range idx.records {
+		enc.uint(uint64(rec.postcode))
+		enc.uint(uint64(rec.recipe))
+		enc.uint(uint64(rec.slot))
+	}
+	for _, lists := range [][][]uint32{idx.byRecipe, idx.byPostcode} {
+		for _, postings := range lists {
+			enc.uint(uint64(len(postings)))
+			prev := uint32(0)
+			for _, i := range postings {
+				enc.uint(uint64(i - prev))
+				prev = i
+			}
+		}
+	}
+	for _, buckets := range idx.buckets {
+		enc.uint(uint64(len(buckets)))
+		for _, b := range buckets {
+			enc.uint(uint64(b.slot))
+			enc.uint(uint64(b.count))
+		}
+	}
+	enc.checksum()
+	return enc.n, errors.Wrap(enc.flush(), api)
+}
+
+// Read читает индекс, записанный WriteTo
+func Read(r io.Reader) (*Index, error) {
+	const api = "index.Read"
+
+	dec := newDecoder(r)
+	if magic := dec.bytes(len(Magic)); dec.err == nil && string(magic) != Magic {
+		return nil, errors.Wrap(ErrNotIndex, api)
+	}
+	if v := dec.uint(); dec.err == nil && v != Version {
+		return nil, errors.Errorf("%s: unsupported index version %d", api, v)
+	}
+	idx := new(Index)
+	idx.recipes = dec.strings()
+	idx.postcodes = dec.strings()
+	n := dec.count(3)
+	idx.slots = make([]ts.Delivery, 0, capHint(n))
+	for i := 0; i < n; i++ {
+		b := dec.bytes(3)
+		if dec.err != nil {
+			break
+		}
+		if b[0] > 6 || b[1] > 23 || b[2] > 23 {
+			dec.fail(errors.Errorf("bad delivery slot %v", b))
+			break
+		}
+		idx.slots = append(idx.slots, ts.ConstructDelivery(ts.Weekday(b[0]), uint(b[1]), uint(b[2])))
+	}
+	n = dec.count(3)
+	idx.records = make([]record, 0, capHint(n))
+	for i := 0; i < n && dec.err == nil; i++ {
+		idx.records = append(idx.records, record{
+			postcode: dec.id(len(idx.postcodes)),
+			recipe:   dec.id(len(idx.recipes)),
+			slot:     dec.id(len(idx.slots)),
+		})
+	}
+	idx.byRecipe = dec.postings(len(idx.recipes), len(idx.records))
+	idx.byPostcode = dec.postings(len(idx.postcodes), len(idx.records))
+	idx.buckets = make([][]bucket, len(idx.postcodes))
+	for pc := range idx.buckets {
+		n = dec.count(2)
+		buckets := make([]bucket, 0, capHint(n))
+		for i := 0; i < n && dec.err == nil; i++ {
+			buckets = append(buckets, bucket{slot: dec.id(len(idx.slots)), count: uint32(dec.uint())})
+		}
+		idx.buckets[pc] = buckets
+	}
+	dec.checksum()
+	if dec.err != nil {
+		return nil, errors.Wrap(dec.err, api)
+	}
+	return idx, nil
+}
+
+// Load читает индекс из файла
+func Load(path string) (*Index, error) {
+	const api = "index.Load"
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s: open file('%s')", api, path)
+	}
+	defer f.Close() //nolint:gosec
+	idx, err := Read(f)
+	return idx, errors.Wrapf(err, "%s: file('%s')", api, path)
+}
+
+// IsIndex проверяет, начинается ли файл с Magic
+func IsIndex(path string) (bool, error) {
+	const api = "index.IsIndex"
+
+	f, err := os.Open(path)
+	if err != nil {
+		return false, errors.Wrapf(err, "%s: open file('%s')", api, path)
+	}
+	defer f.Close() //nolint:gosec
+	head := make([]byte, len(Magic))
+	if _, err = io.ReadFull(f, head); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "%s: read file('%s')", api, path)
+	}
+	return bytes.Equal(head, []byte(Magic)), nil
+}
+
+// ---------------------------------------- IMPL -------------------------------------
+
+type encoder struct {
+	w   *bufio.Writer
+	crc hash.Hash32
+	n   int64
+	err error
+	buf [binary.MaxVarintLen64]byte
+}
+
+func newEncoder(w io.Writer) *encoder {
+	return &encoder{w: bufio.NewWriter(w), crc: crc32.NewIEEE()}
+}
+
+func (e *encoder) bytes(b []byte) {
+	if e.err != nil {
+		return
+	}
+	_, _ = e.crc.Write(b)
+	var n int
+	n, e.err = e.w.Write(b)
+	e.n += int64(n)
+}
+
+func (e *encoder) uint(v uint64) {
+	e.bytes(e.buf[:binary.PutUvarint(e.buf[:], v)])
+}
+
+func (e *encoder) strings(ss []string) {
+	e.uint(uint64(len(ss)))
+	for _, s := range ss {
+		if len(s) > maxString && e.err == nil {
+			e.err = errors.Errorf("string of %d bytes is too long for index", len(s))
+		}
+		e.uint(uint64(len(s)))
+		e.bytes([]byte(s))
+	}
+}
+
+func (e *encoder) checksum() {
+	var sum [4]byte
+	binary.LittleEndian.PutUint32(sum[:], e.crc.Sum32())
+	e.bytes(sum[:])
+}
+
+func (e *encoder) flush() error {
+	if e.err != nil {
+		return e.err
+	}
+	return e.w.Flush()
+}
+
+// maxString длина самой длинной строки словаря
+const maxString = 1 << 16
+
+// capHint ёмкость среза по прочитанной длине: до проверки CRC длине нельзя доверять целиком
+func capHint(n int) int {
+	return min(n, 1<<16)
+}
+
+type decoder struct {
+	r   *bufio.Reader
+	crc hash.Hash32
+	err error
+}
+
+func newDecoder(r io.Reader) *decoder {
+	return &decoder{r: bufio.NewReader(r), crc: crc32.NewIEEE()}
+}
+
+func (d *decoder) fail(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+}
+
+func (d *decoder) bytes(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(d.r, b); err != nil {
+		d.fail(errors.Wrap(io.ErrUnexpectedEOF, "truncated index"))
+		return nil
+	}
+	_, _ = d.crc.Write(b)
+	return b
+}
+
+func (d *decoder) uint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	var v uint64
+	for shift := uint(0); ; shift += 7 {
+		b := d.bytes(1)
+		if d.err != nil {
+			return 0
+		}
+		if shift >= 64 {
+			d.fail(errors.New("varint overflows 64 bits"))
+			return 0
+		}
+		v |= uint64(b[0]&0x7f) << shift
+		if b[0] < 0x80 {
+			return v
+		}
+	}
+}
+
+// count длина списка, каждый элемент которого занимает не меньше minSize байт; защищает от
+// выделения памяти по испорченной длине
+func (d *decoder) count(minSize int) int {
+	v := d.uint()
+	if d.err == nil && v > uint64(1<<31/minSize) {
+		d.fail(errors.Errorf("bad list length %d", v))
+		return 0
+	}
+	return int(v)
+}
+
+func (d *decoder) id(n int) uint32 {
+	v := d.uint()
+	if d.err == nil && v >= uint64(n) {
+		d.fail(errors.Errorf("id %d is out of range [0, %d)", v, n))
+		return 0
+	}
+	return uint32(v)
+}
+
+func (d *decoder) strings() []string {
+	n := d.count(1)
+	ret := make([]string, 0, capHint(n))
+	for i := 0; i < n && d.err == nil; i++ {
+		size := d.uint()
+		if d.err == nil && size > maxString {
+			d.fail(errors.Errorf("bad string length %d", size))
+		}
+		ret = append(ret, string(d.bytes(int(size))))
+	}
+	return ret
+}
+
+func (d *decoder) postings(lists, records int) [][]uint32 {
+	ret := make([][]uint32, lists)
+	for l := range ret {
+		n := d.count(1)
+		postings := make([]uint32, 0, capHint(n))
+		prev := uint64(0)
+		for i := 0; i < n && d.err == nil; i++ {
+			prev += d.uint()
+			if prev >= uint64(records) {
+				d.fail(errors.Errorf("record %d is out of range [0, %d)", prev, records))
+				break
+			}
+			postings = append(postings, uint32(prev))
+		}
+		ret[l] = postings
+	}
+	return ret
+}
+
+func (d *decoder) checksum() {
+	want := d.crc.Sum32()
+	sum := d.bytes(4)
+	if d.err == nil && binary.LittleEndian.Uint32(sum) != want {
+		d.fail(errors.New("index checksum mismatch"))
+	}
+}
@@ -0,0 +1,191 @@
+// Package index компактный двоичный индекс доставок: словари "recipe name" и "postcode", списки
+// доставок (posting lists) по каждому из них и окна доставки по "postcode"
+package index
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors" //nolint:goimports
+	"sber-test/pkg/models"
+	"sber-test/pkg/providers"
+	ts "sber-test/pkg/time-slot" //nolint:goimports
+)
+
+// Index индекс доставок; реализует processors.DeliveryIndex и сам является провайдером доставок
+// (в исходном порядке)
+type Index struct {
+	recipes   []string
+	postcodes []string
+	slots     []ts.Delivery
+	records   []record
+	// byRecipe, byPostcode posting lists: номера записей по возрастанию
+	byRecipe   [][]uint32
+	byPostcode [][]uint32
+	// buckets окна доставки по "postcode" с числом доставок в каждом
+	buckets [][]bucket
+}
+
+// Build строит индекс по доставкам provider
+func Build(ctx context.Context, provider providers.RecipeDeliveryProvider) (*Index, error) {
+	const api = "index.Build"
+
+	items, err := providers.Collect(ctx, provider)
+	if err != nil {
+		return nil, errors.Wrap(err, api)
+	}
+	recipes, postcodes := make(map[string]uint32), make(map[string]uint32)
+	slots := make(map[ts.Delivery]uint32)
+	for _, item := range items {
+		recipes[item.Recipe] = 0
+		postcodes[item.Postcode] = 0
+		slots[item.Delivery] = 0
+	}
+	idx := &Index{
+		recipes:   dictionary(recipes),
+		postcodes: dictionary(postcodes),
+		records:   make([]record, 0, len(items)),
+	}
+	for slot := range slots {
+		idx.slots = append(idx.slots, slot)
+	}
+	sort.Slice(idx.slots, func(i, j int) bool {
+		return slotLess(idx.slots[i], idx.slots[j])
+	})
+	for i, slot := range idx.slots {
+		slots[slot] = uint32(i)
+	}
+	for _, item := range items {
+		idx.records = append(idx.records, record{
+			postcode: postcodes[item.Postcode],
+			recipe:   recipes[item.Recipe],
+			slot:     slots[item.Delivery],
+		})
+	}
+	idx.buildPostings()
+	return idx, nil
+}
+
+// Len число доставок
+func (idx *Index) Len() int {
+	return len(idx.records)
+}
+
+// Provide ...
+func (idx *Index) Provide(ctx context.Context, consumer func(models.RecipeDelivery) error) error {
+	for i, rec := range idx.records {
+		if i%1024 == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		err := consumer(models.RecipeDelivery{
+			Postcode: idx.postcodes[rec.postcode],
+			Recipe:   idx.recipes[rec.recipe],
+			Delivery: idx.slots[rec.slot],
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Recipes уникальные "recipe name" по алфавиту; срез нельзя изменять
+func (idx *Index) Recipes() []string {
+	return idx.recipes
+}
+
+// RecipeCount ...
+func (idx *Index) RecipeCount(recipe string) int {
+	if i, ok := find(idx.recipes, recipe); ok {
+		return len(idx.byRecipe[i])
+	}
+	return 0
+}
+
+// Postcodes уникальные "postcode" по алфавиту; срез нельзя изменять
+func (idx *Index) Postcodes() []string {
+	return idx.postcodes
+}
+
+// PostcodeCount ...
+func (idx *Index) PostcodeCount(postcode string) int {
+	if i, ok := find(idx.postcodes, postcode); ok {
+		return len(idx.byPostcode[i])
+	}
+	return 0
+}
+
+// PostcodeSlots ...
+func (idx *Index) PostcodeSlots(postcode string, fn func(slot ts.Delivery, count int)) {
+	if i, ok := find(idx.postcodes, postcode); ok {
+		for _, b := range idx.buckets[i] {
+			fn(idx.slots[b.slot], int(b.count))
+		}
+	}
+}
+
+// ---------------------------------------- IMPL -------------------------------------
+
+type (
+	record struct {
+		postcode, recipe, slot uint32
+	}
+
+	bucket struct {
+		slot, count uint32
+	}
+)
+
+func dictionary(m map[string]uint32) []string {
+	ret := make([]string, 0, len(m))
+	for s := range m {
+		ret = append(ret, s)
+	}
+	sort.Strings(ret)
+	for i, s := range ret {
+		m[s] = uint32(i)
+	}
+	return ret
+}
+
+func find(dict []string, s string) (int, bool) {
+	i := sort.SearchStrings(dict, s)
+	return i, i < len(dict) && dict[i] == s
+}
+
+func slotLess(l, r ts.Delivery) bool {
+	if l.WDay != r.WDay {
+		return l.WDay < r.WDay
+	}
+	if l.From != r.From {
+		return l.From < r.From
+	}
+	return l.To < r.To
+}
+
+// buildPostings posting lists и окна доставки по записям
+func (idx *Index) buildPostings() {
+	idx.byRecipe = make([][]uint32, len(idx.recipes))
+	idx.byPostcode = make([][]uint32, len(idx.postcodes))
+	for i, rec := range idx.records {
+		idx.byRecipe[rec.recipe] = append(idx.byRecipe[rec.recipe], uint32(i))
+		idx.byPostcode[rec.postcode] = append(idx.byPostcode[rec.postcode], uint32(i))
+	}
+	idx.buckets = make([][]bucket, len(idx.postcodes))
+	for pc, postings := range idx.byPostcode {
+		counts := make(map[uint32]uint32)
+		for _, i := range postings {
+			counts[idx.records[i].slot]++
+		}
+		buckets := make([]bucket, 0, len(counts))
+		for slot, count := range counts {
+			buckets = append(buckets, bucket{slot: slot, count: count})
+		}
+		sort.Slice(buckets, func(i, j int) bool {
+			return buckets[i].slot < buckets[j].slot
+		})
+		idx.buckets[pc] = buckets
+	}
+}
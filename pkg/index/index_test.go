@@ -0,0 +1,140 @@
+package index
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" //nolint:goimports
+	"sber-test/pkg/processors"
+	"sber-test/pkg/providers"
+	ts "sber-test/pkg/time-slot" //nolint:goimports
+)
+
+var testData = providers.RecipeDeliveries{
+	{Recipe: "Ink | Pen", Postcode: "1", Delivery: ts.ConstructDelivery(time.Monday, 10, 15)},
+	{Recipe: "B Potato", Postcode: "2", Delivery: ts.ConstructDelivery(time.Wednesday, 8, 15)},
+	{Recipe: "Ink | Pen", Postcode: "1", Delivery: ts.ConstructDelivery(time.Thursday, 10, 15)},
+	{Recipe: "Veggie Potato", Postcode: "1", Delivery: ts.ConstructDelivery(time.Monday, 10, 15)},
+	{Recipe: "B Potato", Postcode: "3", Delivery: ts.ConstructDelivery(time.Monday, 7, 9)},
+}
+
+func roundTrip(t *testing.T, idx *Index) *Index {
+	var buf bytes.Buffer
+	n, err := idx.WriteTo(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+	ret, err := Read(&buf)
+	assert.NoError(t, err)
+	return ret
+}
+
+func TestIndexRoundTrip(t *testing.T) {
+	idx, err := Build(context.Background(), testData)
+	assert.NoError(t, err)
+	got := roundTrip(t, idx)
+	assert.Equal(t, idx, got)
+
+	items, err := providers.Collect(context.Background(), got)
+	assert.NoError(t, err)
+	assert.Equal(t, testData, items)
+	assert.Equal(t, []string{"B Potato", "Ink | Pen", "Veggie Potato"}, got.Recipes())
+	assert.Equal(t, []string{"1", "2", "3"}, got.Postcodes())
+	assert.Equal(t, 2, got.RecipeCount("Ink | Pen"))
+	assert.Equal(t, 0, got.RecipeCount("Nope"))
+	assert.Equal(t, 3, got.PostcodeCount("1"))
+
+	slots := make(map[ts.Delivery]int)
+	got.PostcodeSlots("1", func(slot ts.Delivery, count int) {
+		slots[slot] = count
+	})
+	assert.Equal(t, map[ts.Delivery]int{
+		ts.ConstructDelivery(time.Monday, 10, 15):   2,
+		ts.ConstructDelivery(time.Thursday, 10, 15): 1,
+	}, slots)
+
+	empty, err := Build(context.Background(), providers.RecipeDeliveries{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, roundTrip(t, empty).Len())
+}
+
+// TestIndexedReport отчёт по индексу совпадает с отчётом по доставкам
+func TestIndexedReport(t *testing.T) {
+	idx, err := Build(context.Background(), testData)
+	assert.NoError(t, err)
+	for _, subjects := range []func() []processors.RecipeReportSubj{
+		func() []processors.RecipeReportSubj {
+			return []processors.RecipeReportSubj{
+				processors.ReportUniqueRecipes(),
+				processors.ReportCounterPerRecipe(),
+				processors.ReportBusiestPostcode(),
+				processors.ReportIfMatchedRecipes("Potato", "Nope"),
+				processors.ReportDeliveryCountForPostcodeAndTime("1", 9, 16),
+			}
+		},
+		func() []processors.RecipeReportSubj {
+			return []processors.RecipeReportSubj{processors.ReportDeliveryCountForPostcodeAndTime("9", 0, 23)}
+		},
+		// subject без ответа по индексу: отчёт строится чтением доставок из индекса
+		func() []processors.RecipeReportSubj {
+			groupBy, err := processors.ReportGroupBy(processors.GroupBySpec{By: []processors.Dimension{processors.DimPostcode}})
+			assert.NoError(t, err)
+			return []processors.RecipeReportSubj{processors.ReportUniqueRecipes(), groupBy}
+		},
+	} {
+		s := subjects()
+		want, err := processors.NewRecipeReportProcessor(s[0], s[1:]...).Process(context.Background(), testData)
+		assert.NoError(t, err)
+		s = subjects()
+		got, err := processors.NewRecipeReportProcessor(s[0], s[1:]...).Process(context.Background(), idx)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestReadCorrupted(t *testing.T) {
+	idx, err := Build(context.Background(), testData)
+	assert.NoError(t, err)
+	var buf bytes.Buffer
+	_, err = idx.WriteTo(&buf)
+	assert.NoError(t, err)
+	data := buf.Bytes()
+
+	_, err = Read(bytes.NewReader(data[:len(data)-1]))
+	assert.Error(t, err)
+	flipped := append([]byte(nil), data...)
+	flipped[len(flipped)/2] ^= 0x01
+	_, err = Read(bytes.NewReader(flipped))
+	assert.Error(t, err)
+	_, err = Read(bytes.NewReader([]byte(`[{"postcode": "1"}]`)))
+	assert.ErrorIs(t, err, ErrNotIndex)
+}
+
+func TestIsIndex(t *testing.T) {
+	dir := t.TempDir()
+	idx, err := Build(context.Background(), testData)
+	assert.NoError(t, err)
+	var buf bytes.Buffer
+	_, err = idx.WriteTo(&buf)
+	assert.NoError(t, err)
+	for name, c := range map[string]struct {
+		data []byte
+		want bool
+	}{
+		"data.idx":   {buf.Bytes(), true},
+		"data.json":  {[]byte(`[]`), false},
+		"empty.json": {nil, false},
+	} {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, c.data, 0o600))
+		got, err := IsIndex(path)
+		assert.NoError(t, err, name)
+		assert.Equal(t, c.want, got, name)
+	}
+	loaded, err := Load(filepath.Join(dir, "data.idx"))
+	assert.NoError(t, err)
+	assert.Equal(t, idx, loaded)
+}
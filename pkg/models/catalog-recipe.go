@@ -0,0 +1,20 @@
+package models
+
+// CatalogRecipe рецепт из каталога
+type CatalogRecipe struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Category string   `json:"category"`
+	Tags     []string `json:"tags"`
+	Calories int      `json:"calories"`
+}
+
+// HasTag ...
+func (r *CatalogRecipe) HasTag(tag string) bool {
+	for _, t := range r.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -9,4 +9,10 @@ type RecipeDelivery struct {
 	Postcode string      `json:"postcode"`
 	Recipe   string      `json:"recipe"`
 	Delivery ts.Delivery `json:"delivery"`
+	// Region зона доставки, если подключен справочник зон и "postcode" в нём найден; из входных данных не читается
+	Region *Region `json:"-"`
+	// Catalog рецепт из каталога, если каталог подключен и рецепт в нём найден; из входных данных не читается
+	Catalog *CatalogRecipe `json:"-"`
+	// OriginPostcode исходный "postcode", если Postcode заменён при свёртке по районам/зонам
+	OriginPostcode string `json:"-"`
 }
@@ -0,0 +1,8 @@
+package models
+
+// Region зона доставки, к которой относится "postcode"
+type Region struct {
+	Zone  string `json:"zone"`
+	City  string `json:"city"`
+	Depot string `json:"depot"`
+}
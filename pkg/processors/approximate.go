@@ -0,0 +1,84 @@
+package processors
+
+import (
+	"math"
+
+	"sber-test/pkg/models"
+	"sber-test/pkg/sketch"
+)
+
+// DefaultApproxCapacity начальная ёмкость фильтра уникальных пар (postcode, доставка) для приближённого
+// busiest postcode; фильтр растёт вместе с входными данными
+const DefaultApproxCapacity = 1 << 16
+
+// MinApproxErrorRate наименьшая допустимая относительная погрешность приближённого подсчёта:
+// размер Count-Min Sketch обратно пропорционален погрешности
+const MinApproxErrorRate = 1e-4
+
+// approxConfidence вероятность, с которой Count-Min Sketch укладывается в ErrorBound
+const approxConfidence = 0.99
+
+// ReportUniqueRecipesApprox Подсчитать число уникальных "recipe name" приближённо (HyperLogLog)
+// с относительной стандартной ошибкой errRate
+func ReportUniqueRecipesApprox(errRate float64) RecipeReportSubj {
+	return &approxUniqueRecipeCounter{hll: sketch.NewHyperLogLog(errRate)}
+}
+
+// ReportBusiestPostcodeApprox Найти "postcode" с наибольшим числом доставок приближённо (Count-Min Sketch);
+// доставки различаются растущим фильтром Блума с начальной ёмкостью capacity уникальных пар (postcode, доставка).
+// Погрешность двусторонняя: ложные срабатывания фильтра занижают счётчик (в среднем не более чем на errRate доли),
+// Count-Min Sketch завышает его; DeliveryCountError покрывает обе
+func ReportBusiestPostcodeApprox(errRate float64, capacity uint64) RecipeReportSubj {
+	if capacity == 0 {
+		capacity = DefaultApproxCapacity
+	}
+	return &approxBusiestPostcodeReporter{
+		errRate: errRate,
+		seen:    sketch.NewScalableBloomFilter(capacity, errRate),
+		cms:     sketch.NewCountMinSketch(errRate, 1-approxConfidence),
+	}
+}
+
+// ---------------------------------------- IMPL -------------------------------------
+
+type approxUniqueRecipeCounter struct {
+	hll *sketch.HyperLogLog
+}
+
+func (r *approxUniqueRecipeCounter) consume(item models.RecipeDelivery) {
+	r.hll.Add(sketch.Hash(item.Recipe))
+}
+
+func (r *approxUniqueRecipeCounter) fillReport(rep *RecipeProcessorReport) {
+	n := int(r.hll.Estimate())
+	margin := int(math.Ceil(float64(n) * r.hll.StdError()))
+	rep.UniqueRecipeCount = &n
+	rep.UniqueRecipeCountError = &margin
+}
+
+type approxBusiestPostcodeReporter struct {
+	errRate float64
+	seen    *sketch.ScalableBloomFilter
+	cms     *sketch.CountMinSketch
+	busiest busiestPostcode
+}
+
+func (r *approxBusiestPostcodeReporter) consume(item models.RecipeDelivery) {
+	pd := postcodeDeliveryOf(item)
+	if r.seen.TestAndAdd(sketch.Hash(pd.postcode + "\x00" + pd.delivery.String())) {
+		return
+	}
+	if n := int(r.cms.Add(sketch.Hash(item.Postcode), 1)); n > r.busiest.DeliveryCount {
+		r.busiest.Postcode = item.Postcode
+		r.busiest.DeliveryCount = n
+	}
+}
+
+func (r *approxBusiestPostcodeReporter) fillReport(rep *RecipeProcessorReport) {
+	busiest := r.busiest
+	// Count-Min Sketch завышает счётчик не более чем на ErrorBound, ложные срабатывания фильтра Блума
+	// занижают его в среднем не более чем на errRate доли
+	margin := int(r.cms.ErrorBound()) + int(math.Ceil(float64(busiest.DeliveryCount)*r.errRate))
+	busiest.DeliveryCountError = &margin
+	rep.BusiestPostcode = &busiest
+}
@@ -0,0 +1,213 @@
+package processors
+
+import (
+	"sort"
+
+	"github.com/pkg/errors" //nolint:goimports
+	"sber-test/pkg/models"  //nolint:goimports
+)
+
+// maxUnknownRecipes сколько различных "recipe name", отсутствующих в каталоге, перечислять в отчёте
+const maxUnknownRecipes = 100
+
+type (
+	countPerCategory struct {
+		Category string `json:"category"`
+		Count    int    `json:"count"`
+		Recipes  int    `json:"recipes"`
+	}
+
+	countPerTag struct {
+		Tag   string `json:"tag"`
+		Count int    `json:"count"`
+	}
+
+	tagShare struct {
+		Tag           string  `json:"tag"`
+		DeliveryCount int     `json:"delivery_count"`
+		TotalCount    int     `json:"total_count"`
+		Share         float64 `json:"share"`
+	}
+
+	unknownRecipes struct {
+		Count   int              `json:"count"`
+		Recipes []countPerRecipe `json:"recipes"`
+	}
+)
+
+// JoinCatalog стадия, дополняющая доставку рецептом из каталога; рецепты сопоставляются
+// по NormalizeRecipeName. Рецепты, которых нет в каталоге, перечисляются в отчёте
+func JoinCatalog(catalog []models.CatalogRecipe) (RecipeDeliveryStage, error) {
+	const api = "JoinCatalog"
+
+	ret := &catalogJoiner{
+		recipes: make(map[string]*models.CatalogRecipe, len(catalog)),
+		unknown: counterPerRecipe{counter: make(map[string]int)},
+	}
+	for i := range catalog {
+		r := &catalog[i]
+		k := NormalizeRecipeName(r.Name)
+		if prev, ok := ret.recipes[k]; ok {
+			return nil, errors.Errorf("%s: recipes '%s' and '%s' are indistinguishable", api, prev.Name, r.Name)
+		}
+		ret.recipes[k] = r
+	}
+	return ret, nil
+}
+
+// ReportCountPerCategory Подсчитать число доставок по категориям каталога (требует JoinCatalog)
+func ReportCountPerCategory() RecipeReportSubj {
+	return &catalogCounter{
+		counterPerRecipe: counterPerRecipe{counter: make(map[string]int)},
+		recipes:          make(map[string]*models.CatalogRecipe),
+		byCategory:       true,
+	}
+}
+
+// ReportCountPerTag Подсчитать число доставок по тегам каталога (требует JoinCatalog)
+func ReportCountPerTag() RecipeReportSubj {
+	return &catalogCounter{
+		counterPerRecipe: counterPerRecipe{counter: make(map[string]int)},
+		recipes:          make(map[string]*models.CatalogRecipe),
+	}
+}
+
+// ReportTagShare Посчитать долю доставок рецептов с тегом (например "vegetarian") среди всех доставок
+// (требует JoinCatalog)
+func ReportTagShare(tag string, optional ...string) RecipeReportSubj {
+	return &tagShareCounter{
+		tags:   append(append([]string(nil), tag), optional...),
+		counts: make(map[string]int),
+	}
+}
+
+// HasCatalog среди стадий есть JoinCatalog
+func HasCatalog(stages ...RecipeDeliveryStage) bool {
+	for _, s := range stages {
+		if _, ok := s.(*catalogJoiner); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ---------------------------------------- IMPL -------------------------------------
+
+type catalogJoiner struct {
+	recipes map[string]*models.CatalogRecipe
+	count   int
+	unknown counterPerRecipe
+}
+
+func (r *catalogJoiner) apply(item *models.RecipeDelivery) bool {
+	if item.Catalog = r.recipes[NormalizeRecipeName(item.Recipe)]; item.Catalog == nil {
+		r.count++
+		if _, ok := r.unknown.counter[item.Recipe]; ok || len(r.unknown.counter) < maxUnknownRecipes {
+			r.unknown.consume(*item)
+		}
+	}
+	return true
+}
+
+func (r *catalogJoiner) fillReport(rep *RecipeProcessorReport) {
+	var tmp RecipeProcessorReport
+	r.unknown.fillReport(&tmp)
+	rep.UnknownRecipes = &unknownRecipes{Count: r.count, Recipes: tmp.CountPerRecipe}
+}
+
+// catalogCounter считает доставки по рецептам (как counterPerRecipe) и сворачивает их
+// в категории или теги при заполнении отчёта
+type catalogCounter struct {
+	counterPerRecipe
+	recipes    map[string]*models.CatalogRecipe
+	byCategory bool
+}
+
+func (r *catalogCounter) consume(item models.RecipeDelivery) {
+	if item.Catalog == nil {
+		return
+	}
+	r.counterPerRecipe.consume(item)
+	r.recipes[item.Recipe] = item.Catalog
+}
+
+func (r *catalogCounter) fillReport(rep *RecipeProcessorReport) {
+	if r.byCategory {
+		r.fillCategories(rep)
+	} else {
+		r.fillTags(rep)
+	}
+}
+
+func (r *catalogCounter) fillCategories(rep *RecipeProcessorReport) {
+	counts := make(map[string]*countPerCategory)
+	recipes := make(map[string]map[string]struct{})
+	for name, n := range r.counter {
+		cat := r.recipes[name]
+		c := counts[cat.Category]
+		if c == nil {
+			c = &countPerCategory{Category: cat.Category}
+			counts[cat.Category] = c
+			recipes[cat.Category] = make(map[string]struct{})
+		}
+		c.Count += n
+		recipes[cat.Category][cat.ID+"\x00"+cat.Name] = struct{}{}
+	}
+	items := make([]countPerCategory, 0, len(counts))
+	for category, c := range counts {
+		c.Recipes = len(recipes[category])
+		items = append(items, *c)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Category < items[j].Category
+	})
+	rep.CountPerCategory = items
+}
+
+func (r *catalogCounter) fillTags(rep *RecipeProcessorReport) {
+	counts := make(map[string]int)
+	for name, n := range r.counter {
+		for _, tag := range r.recipes[name].Tags {
+			counts[tag] += n
+		}
+	}
+	items := make([]countPerTag, 0, len(counts))
+	for tag, n := range counts {
+		items = append(items, countPerTag{Tag: tag, Count: n})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Tag < items[j].Tag
+	})
+	rep.CountPerTag = items
+}
+
+type tagShareCounter struct {
+	tags   []string
+	total  int
+	counts map[string]int
+}
+
+func (r *tagShareCounter) consume(item models.RecipeDelivery) {
+	r.total++
+	if item.Catalog == nil {
+		return
+	}
+	for _, tag := range r.tags {
+		if item.Catalog.HasTag(tag) {
+			r.counts[tag]++
+		}
+	}
+}
+
+func (r *tagShareCounter) fillReport(rep *RecipeProcessorReport) {
+	if rep.TagShares == nil {
+		rep.TagShares = make([]tagShare, 0, len(r.tags))
+	}
+	for _, tag := range r.tags {
+		share := tagShare{Tag: tag, DeliveryCount: r.counts[tag], TotalCount: r.total}
+		if r.total > 0 {
+			share.Share = float64(share.DeliveryCount) / float64(r.total)
+		}
+		rep.TagShares = append(rep.TagShares, share)
+	}
+}
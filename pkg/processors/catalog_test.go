@@ -0,0 +1,57 @@
+package processors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert" //nolint:goimports
+	"sber-test/pkg/models"
+	"sber-test/pkg/providers" //nolint:goimports
+)
+
+var testCatalog = []models.CatalogRecipe{
+	{ID: "1", Name: "Tex-Mex Tilapia", Category: "fish", Tags: []string{"spicy"}},
+	{ID: "2", Name: "Mediterranean Baked Veggies", Category: "veggie", Tags: []string{"vegetarian", "vegan"}},
+	{ID: "3", Name: "Creamy Mushroom Pasta", Category: "veggie", Tags: []string{"vegetarian"}},
+}
+
+func TestJoinCatalog(t *testing.T) {
+	data := providers.RecipeDeliveries{
+		{Recipe: "Tex-Mex Tilapia"},
+		{Recipe: "tex mex tilapia"},
+		{Recipe: "Mediterranean Baked Veggies"},
+		{Recipe: "Creamy Mushroom Pasta"},
+		// рецепта нет в каталоге: переданный с доставкой рецепт каталога не учитывается
+		{Recipe: "Speedy Steak Fajitas", Catalog: &models.CatalogRecipe{Name: "Speedy Steak Fajitas", Category: "fish"}},
+	}
+	stage, err := JoinCatalog(testCatalog)
+	assert.NoError(t, err)
+	proc := NewRecipeReportProcessor(
+		ReportCountPerCategory(),
+		ReportCountPerTag(),
+		ReportTagShare("vegetarian"),
+	).WithStages(stage)
+	report, err := proc.Process(context.Background(), data)
+	assert.NoError(t, err)
+	assert.Equal(t, []countPerCategory{
+		{Category: "fish", Count: 2, Recipes: 1},
+		{Category: "veggie", Count: 2, Recipes: 2},
+	}, report.CountPerCategory)
+	assert.Equal(t, []countPerTag{
+		{Tag: "spicy", Count: 2},
+		{Tag: "vegan", Count: 1},
+		{Tag: "vegetarian", Count: 2},
+	}, report.CountPerTag)
+	assert.Equal(t, []tagShare{
+		{Tag: "vegetarian", DeliveryCount: 2, TotalCount: 5, Share: 0.4},
+	}, report.TagShares)
+	assert.Equal(t, &unknownRecipes{
+		Count:   1,
+		Recipes: []countPerRecipe{{Recipe: "Speedy Steak Fajitas", Count: 1}},
+	}, report.UnknownRecipes)
+}
+
+func TestJoinCatalogDuplicates(t *testing.T) {
+	_, err := JoinCatalog([]models.CatalogRecipe{{Name: "Tex-Mex Tilapia"}, {Name: "tex mex tilapia"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,30 @@
+package processors
+
+import (
+	"sber-test/pkg/models"
+)
+
+// RecipeDeliveryPredicate условие отбора доставок
+type RecipeDeliveryPredicate func(models.RecipeDelivery) bool
+
+// ReportFiltered передаёт в subj только доставки, удовлетворяющие pred
+func ReportFiltered(pred RecipeDeliveryPredicate, subj RecipeReportSubj) RecipeReportSubj {
+	return &filteredSubj{pred: pred, subj: subj}
+}
+
+// ---------------------------------------- IMPL -------------------------------------
+
+type filteredSubj struct {
+	pred RecipeDeliveryPredicate
+	subj RecipeReportSubj
+}
+
+func (r *filteredSubj) consume(item models.RecipeDelivery) {
+	if r.pred(item) {
+		r.subj.consume(item)
+	}
+}
+
+func (r *filteredSubj) fillReport(rep *RecipeProcessorReport) {
+	r.subj.fillReport(rep)
+}
@@ -0,0 +1,327 @@
+package processors
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors" //nolint:goimports
+	"sber-test/pkg/models"  //nolint:goimports
+)
+
+type (
+	// Dimension измерение, по которому группируются доставки
+	Dimension int
+
+	// GroupOrder правило сортировки строк агрегации
+	GroupOrder struct {
+		// Column имя измерения, OrderColumnCount или OrderColumnDistinct
+		Column string
+		Desc   bool
+	}
+
+	// GroupBySpec описание агрегации
+	GroupBySpec struct {
+		By            []Dimension
+		CountDistinct Dimension
+		OrderBy       []GroupOrder
+		Limit         int
+	}
+
+	aggregationRow struct {
+		Key      []string `json:"key"`
+		Count    int      `json:"count"`
+		Distinct *int     `json:"distinct,omitempty"`
+	}
+
+	aggregation struct {
+		GroupBy       []string         `json:"group_by"`
+		CountDistinct string           `json:"count_distinct,omitempty"`
+		Rows          []aggregationRow `json:"rows"`
+		// Truncated строки сверх GroupBySpec.Limit отброшены
+		Truncated bool `json:"truncated,omitempty"`
+	}
+)
+
+// Dimensions
+const (
+	DimNone Dimension = iota
+	DimPostcode
+	DimRecipe
+	DimWeekday
+	DimFrom
+	DimTo
+	DimZone
+	DimCity
+	DimDepot
+)
+
+// Order columns besides dimensions
+const (
+	OrderColumnCount    = "count"
+	OrderColumnDistinct = "distinct"
+)
+
+var dimNames = map[Dimension]string{
+	DimPostcode: "postcode",
+	DimRecipe:   "recipe",
+	DimWeekday:  "weekday",
+	DimFrom:     "from",
+	DimTo:       "to",
+	DimZone:     "zone",
+	DimCity:     "city",
+	DimDepot:    "depot",
+}
+
+func (d Dimension) String() string {
+	if s, ok := dimNames[d]; ok {
+		return s
+	}
+	return ""
+}
+
+// IsRegion измерение берётся из зоны доставки и требует стадии EnrichRegions
+func (d Dimension) IsRegion() bool {
+	return d == DimZone || d == DimCity || d == DimDepot
+}
+
+// NeedsRegions агрегация обращается к измерениям зоны доставки
+func (s GroupBySpec) NeedsRegions() bool {
+	if s.CountDistinct.IsRegion() {
+		return true
+	}
+	for _, d := range s.By {
+		if d.IsRegion() {
+			return true
+		}
+	}
+	for _, o := range s.OrderBy {
+		if d, e := ParseDimension(o.Column); e == nil && d.IsRegion() {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseDimension ...
+func ParseDimension(s string) (Dimension, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for d, name := range dimNames {
+		if name == s {
+			return d, nil
+		}
+	}
+	return DimNone, errors.Errorf("unknown dimension '%s'", s)
+}
+
+// ParseGroupOrder разбирает 'column[:asc|:desc]'
+func ParseGroupOrder(s string) (GroupOrder, error) {
+	var ret GroupOrder
+	parts := strings.SplitN(strings.ToLower(strings.TrimSpace(s)), ":", 2)
+	ret.Column = parts[0]
+	if len(parts) == 2 {
+		switch parts[1] {
+		case "asc":
+		case "desc":
+			ret.Desc = true
+		default:
+			return ret, errors.Errorf("bad order direction '%s'", parts[1])
+		}
+	}
+	switch ret.Column {
+	case OrderColumnCount, OrderColumnDistinct:
+	default:
+		if _, e := ParseDimension(ret.Column); e != nil {
+			return ret, errors.Errorf("bad order column '%s'", ret.Column)
+		}
+	}
+	return ret, nil
+}
+
+// Value значение измерения для доставки
+func (d Dimension) Value(item models.RecipeDelivery) string {
+	switch d {
+	case DimPostcode:
+		return item.Postcode
+	case DimRecipe:
+		return item.Recipe
+	case DimWeekday:
+		return item.Delivery.WDay.String()
+	case DimFrom:
+		return item.Delivery.From.String()
+	case DimTo:
+		return item.Delivery.To.String()
+	}
+	if item.Region == nil {
+		return ""
+	}
+	switch d {
+	case DimZone:
+		return item.Region.Zone
+	case DimCity:
+		return item.Region.City
+	case DimDepot:
+		return item.Region.Depot
+	}
+	return ""
+}
+
+func (d Dimension) compare(l, r models.RecipeDelivery) int {
+	switch d {
+	case DimWeekday:
+		return compareInts(int(l.Delivery.WDay), int(r.Delivery.WDay))
+	case DimFrom:
+		return compareInts(int(l.Delivery.From), int(r.Delivery.From))
+	case DimTo:
+		return compareInts(int(l.Delivery.To), int(r.Delivery.To))
+	}
+	return strings.Compare(d.Value(l), d.Value(r))
+}
+
+func compareInts(l, r int) int {
+	if l < r {
+		return -1
+	}
+	if l > r {
+		return 1
+	}
+	return 0
+}
+
+// ReportGroupBy Сгруппировать доставки по произвольному набору измерений и подсчитать их число;
+// без измерений считается общее число доставок
+func ReportGroupBy(spec GroupBySpec) (RecipeReportSubj, error) {
+	const api = "ReportGroupBy"
+
+	for _, d := range spec.By {
+		if _, ok := dimNames[d]; !ok {
+			return nil, errors.Errorf("%s: unknown group by dimension %d", api, int(d))
+		}
+	}
+	if _, ok := dimNames[spec.CountDistinct]; !ok && spec.CountDistinct != DimNone {
+		return nil, errors.Errorf("%s: unknown count distinct dimension %d", api, int(spec.CountDistinct))
+	}
+	for _, o := range spec.OrderBy {
+		if o.Column == OrderColumnDistinct && spec.CountDistinct == DimNone {
+			return nil, errors.Errorf("%s: order by '%s' requires count distinct", api, o.Column)
+		}
+	}
+	return &groupByCounter{
+		spec: spec,
+		rows: make(map[string]*groupByRow),
+	}, nil
+}
+
+// ---------------------------------------- IMPL -------------------------------------
+
+type groupByRow struct {
+	sample   models.RecipeDelivery
+	count    int
+	distinct map[string]struct{}
+}
+
+type groupByCounter struct {
+	spec GroupBySpec
+	rows map[string]*groupByRow
+}
+
+func (r *groupByCounter) key(item models.RecipeDelivery) string {
+	var b strings.Builder
+	for i, d := range r.spec.By {
+		if i > 0 {
+			b.WriteByte(0)
+		}
+		b.WriteString(d.Value(item))
+	}
+	return b.String()
+}
+
+func (r *groupByCounter) consume(item models.RecipeDelivery) {
+	k := r.key(item)
+	row := r.rows[k]
+	if row == nil {
+		row = &groupByRow{sample: item}
+		if r.spec.CountDistinct != DimNone {
+			row.distinct = make(map[string]struct{})
+		}
+		r.rows[k] = row
+	}
+	row.count++
+	if row.distinct != nil {
+		row.distinct[r.spec.CountDistinct.Value(item)] = struct{}{}
+	}
+}
+
+func (r *groupByCounter) less(l, rr *groupByRow) bool {
+	for _, o := range r.spec.OrderBy {
+		var c int
+		switch o.Column {
+		case OrderColumnCount:
+			c = compareInts(l.count, rr.count)
+		case OrderColumnDistinct:
+			c = compareInts(len(l.distinct), len(rr.distinct))
+		default:
+			d, _ := ParseDimension(o.Column)
+			c = d.compare(l.sample, rr.sample)
+		}
+		if o.Desc {
+			c = -c
+		}
+		if c != 0 {
+			return c < 0
+		}
+	}
+	for _, d := range r.spec.By {
+		if c := d.compare(l.sample, rr.sample); c != 0 {
+			return c < 0
+		}
+	}
+	return false
+}
+
+func (r *groupByCounter) fillReport(rep *RecipeProcessorReport) {
+	rows := make([]*groupByRow, 0, len(r.rows))
+	for _, row := range r.rows {
+		rows = append(rows, row)
+	}
+	if len(r.spec.By) == 0 && len(rows) == 0 {
+		// как в SQL: агрегат без GROUP BY по пустым данным - одна строка с нулем
+		row := &groupByRow{}
+		if r.spec.CountDistinct != DimNone {
+			row.distinct = make(map[string]struct{})
+		}
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return r.less(rows[i], rows[j])
+	})
+	truncated := r.spec.Limit > 0 && len(rows) > r.spec.Limit
+	if truncated {
+		rows = rows[:r.spec.Limit]
+	}
+	agg := aggregation{
+		GroupBy:   make([]string, 0, len(r.spec.By)),
+		Rows:      make([]aggregationRow, 0, len(rows)),
+		Truncated: truncated,
+	}
+	for _, d := range r.spec.By {
+		agg.GroupBy = append(agg.GroupBy, d.String())
+	}
+	if r.spec.CountDistinct != DimNone {
+		agg.CountDistinct = r.spec.CountDistinct.String()
+	}
+	for _, row := range rows {
+		out := aggregationRow{
+			Key:   make([]string, 0, len(r.spec.By)),
+			Count: row.count,
+		}
+		for _, d := range r.spec.By {
+			out.Key = append(out.Key, d.Value(row.sample))
+		}
+		if row.distinct != nil {
+			n := len(row.distinct)
+			out.Distinct = &n
+		}
+		agg.Rows = append(agg.Rows, out)
+	}
+	rep.Aggregations = append(rep.Aggregations, agg)
+}
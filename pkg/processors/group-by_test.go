@@ -0,0 +1,95 @@
+package processors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" //nolint:goimports
+	"sber-test/pkg/models"
+	ts "sber-test/pkg/time-slot" //nolint:goimports
+)
+
+func TestReportGroupBy(t *testing.T) {
+	data := []models.RecipeDelivery{
+		{Recipe: "Ink", Postcode: "1", Delivery: ts.ConstructDelivery(time.Monday, 10, 15)},
+		{Recipe: "Ink", Postcode: "1", Delivery: ts.ConstructDelivery(time.Thursday, 10, 15)},
+		{Recipe: "Ink", Postcode: "1", Delivery: ts.ConstructDelivery(time.Thursday, 18, 22)},
+		{Recipe: "B Potato", Postcode: "2", Delivery: ts.ConstructDelivery(time.Wednesday, 8, 15)},
+		{Recipe: "A Veggie", Postcode: "1", Delivery: ts.ConstructDelivery(time.Saturday, 11, 20)},
+		{Recipe: "C Mushroom", Postcode: "1", Delivery: ts.ConstructDelivery(time.Saturday, 11, 20)},
+		{Recipe: "C Mushroom", Postcode: "5", Delivery: ts.ConstructDelivery(time.Friday, 11, 22)},
+	}
+	rep, err := ReportGroupBy(GroupBySpec{
+		By:            []Dimension{DimPostcode, DimWeekday},
+		CountDistinct: DimRecipe,
+		OrderBy:       []GroupOrder{{Column: OrderColumnCount, Desc: true}},
+		Limit:         3,
+	})
+	assert.NoError(t, err)
+	for _, item := range data {
+		rep.consume(item)
+	}
+	var report RecipeProcessorReport
+	rep.fillReport(&report)
+	assert.Len(t, report.Aggregations, 1)
+	agg := report.Aggregations[0]
+	assert.Equal(t, []string{"postcode", "weekday"}, agg.GroupBy)
+	assert.Equal(t, "recipe", agg.CountDistinct)
+	assert.True(t, agg.Truncated)
+	two, one := 2, 1
+	expected := []aggregationRow{
+		{Key: []string{"1", "Thursday"}, Count: 2, Distinct: &one},
+		{Key: []string{"1", "Saturday"}, Count: 2, Distinct: &two},
+		{Key: []string{"1", "Monday"}, Count: 1, Distinct: &one},
+	}
+	assert.Equal(t, expected, agg.Rows)
+}
+
+func TestReportGroupByHourOrder(t *testing.T) {
+	data := []models.RecipeDelivery{
+		{Recipe: "Ink", Delivery: ts.ConstructDelivery(time.Monday, 10, 15)},
+		{Recipe: "Ink", Delivery: ts.ConstructDelivery(time.Monday, 9, 15)},
+		{Recipe: "Ink", Delivery: ts.ConstructDelivery(time.Monday, 13, 15)},
+	}
+	rep, err := ReportGroupBy(GroupBySpec{By: []Dimension{DimFrom}})
+	assert.NoError(t, err)
+	for _, item := range data {
+		rep.consume(item)
+	}
+	var report RecipeProcessorReport
+	rep.fillReport(&report)
+	var keys []string
+	for _, row := range report.Aggregations[0].Rows {
+		keys = append(keys, row.Key[0])
+	}
+	assert.Equal(t, []string{"9AM", "10AM", "1PM"}, keys)
+}
+
+func TestReportGroupByBadSpec(t *testing.T) {
+	_, err := ReportGroupBy(GroupBySpec{By: []Dimension{Dimension(100)}})
+	assert.Error(t, err)
+	_, err = ReportGroupBy(GroupBySpec{By: []Dimension{DimRecipe, DimNone}})
+	assert.Error(t, err)
+	_, err = ReportGroupBy(GroupBySpec{CountDistinct: Dimension(100)})
+	assert.Error(t, err)
+	_, err = ReportGroupBy(GroupBySpec{
+		By:      []Dimension{DimRecipe},
+		OrderBy: []GroupOrder{{Column: OrderColumnDistinct}},
+	})
+	assert.Error(t, err)
+}
+
+func TestReportGroupByEmpty(t *testing.T) {
+	rep, err := ReportGroupBy(GroupBySpec{CountDistinct: DimRecipe})
+	assert.NoError(t, err)
+	var report RecipeProcessorReport
+	rep.fillReport(&report)
+	zero := 0
+	assert.Equal(t, []aggregationRow{{Key: []string{}, Count: 0, Distinct: &zero}}, report.Aggregations[0].Rows)
+
+	rep, err = ReportGroupBy(GroupBySpec{By: []Dimension{DimRecipe}})
+	assert.NoError(t, err)
+	report = RecipeProcessorReport{}
+	rep.fillReport(&report)
+	assert.Empty(t, report.Aggregations[0].Rows)
+}
@@ -0,0 +1,95 @@
+package processors
+
+import (
+	"sort"
+	"strings"
+
+	"sber-test/pkg/providers"
+	ts "sber-test/pkg/time-slot" //nolint:goimports
+)
+
+// DeliveryIndex источник доставок с готовыми списками по "recipe name" и "postcode"; если отчёт
+// без стадий и все его subjects умеют отвечать по индексу, Process не читает доставки по одной
+type DeliveryIndex interface {
+	providers.RecipeDeliveryProvider
+	// Recipes уникальные "recipe name" по алфавиту
+	Recipes() []string
+	// RecipeCount число доставок "recipe name"
+	RecipeCount(recipe string) int
+	// Postcodes уникальные "postcode" по алфавиту
+	Postcodes() []string
+	// PostcodeSlots окна доставки в "postcode" и число доставок в каждом
+	PostcodeSlots(postcode string, fn func(slot ts.Delivery, count int))
+}
+
+// ---------------------------------------- IMPL -------------------------------------
+
+// indexedSubj subject, который заполняет своё состояние по индексу
+type indexedSubj interface {
+	fromIndex(DeliveryIndex)
+}
+
+// processIndexed отвечает по индексу, если это возможно
+func (rp *RecipeReportProcessor) processIndexed(provider providers.RecipeDeliveryProvider) bool {
+	idx, ok := provider.(DeliveryIndex)
+	if !ok || len(rp.stages) > 0 {
+		return false
+	}
+	subjects := make([]indexedSubj, 0, len(rp.reporters))
+	for _, rep := range rp.reporters {
+		subj, ok := rep.(indexedSubj)
+		if !ok {
+			return false
+		}
+		subjects = append(subjects, subj)
+	}
+	for _, subj := range subjects {
+		subj.fromIndex(idx)
+	}
+	return true
+}
+
+func (r *uniqueRecipeCounter) fromIndex(idx DeliveryIndex) {
+	for _, recipe := range idx.Recipes() {
+		r.counter[recipe] = struct{}{}
+	}
+}
+
+func (r *counterPerRecipe) fromIndex(idx DeliveryIndex) {
+	for _, recipe := range idx.Recipes() {
+		r.counter[recipe] = idx.RecipeCount(recipe)
+	}
+}
+
+func (r *recipeMatchByName) fromIndex(idx DeliveryIndex) {
+	for _, recipe := range idx.Recipes() {
+		for i := range r.names {
+			if strings.Contains(recipe, r.names[i]) {
+				r.res[recipe] = struct{}{}
+				break
+			}
+		}
+	}
+}
+
+func (r *busiestPostcodeReporter) fromIndex(idx DeliveryIndex) {
+	for _, postcode := range idx.Postcodes() {
+		counter := make(map[postcodeDelivery]struct{})
+		idx.PostcodeSlots(postcode, func(slot ts.Delivery, _ int) {
+			counter[postcodeDelivery{postcode: postcode, delivery: slot}] = struct{}{}
+		})
+		r.postalCodeCounter[postcode] = counter
+	}
+}
+
+func (r *counterPerPostcodeAndTime) fromIndex(idx DeliveryIndex) {
+	postcodes := idx.Postcodes()
+	if i := sort.SearchStrings(postcodes, r.Postcode); i == len(postcodes) || postcodes[i] != r.Postcode {
+		return
+	}
+	idx.PostcodeSlots(r.Postcode, func(slot ts.Delivery, count int) {
+		if r.From <= slot.From && slot.To <= r.To {
+			r.DeliveryCount += count
+		}
+	})
+}
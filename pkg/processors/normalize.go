@@ -0,0 +1,95 @@
+package processors
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases" //nolint:goimports
+	"golang.org/x/text/unicode/norm"
+	"sber-test/pkg/models" //nolint:goimports
+)
+
+type mergedRecipe struct {
+	Recipe   string   `json:"recipe"`
+	Variants []string `json:"variants"`
+}
+
+// NormalizeRecipeName ключ сравнения "recipe name": NFKC, case folding,
+// знаки препинания и пробельные символы схлопываются в один пробел
+func NormalizeRecipeName(s string) string {
+	s = cases.Fold().String(norm.NFKC.String(s))
+	var b strings.Builder
+	sep := false
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r) {
+			if sep && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			sep = false
+			b.WriteRune(r)
+		} else {
+			sep = true
+		}
+	}
+	return b.String()
+}
+
+// NormalizeRecipes стадия, приводящая варианты написания "recipe name" к одному каноническому имени.
+// aliases: вариант -> каноническое имя; для остальных каноническим становится первый встреченный вариант
+func NormalizeRecipes(aliases map[string]string) RecipeDeliveryStage {
+	ret := &recipeNormalizer{
+		canonical: make(map[string]string),
+		variants:  make(map[string]map[string]struct{}),
+	}
+	for variant, name := range aliases {
+		ret.canonical[NormalizeRecipeName(variant)] = name
+		if k := NormalizeRecipeName(name); len(ret.canonical[k]) == 0 {
+			ret.canonical[k] = name
+		}
+	}
+	return ret
+}
+
+// ---------------------------------------- IMPL -------------------------------------
+
+type recipeNormalizer struct {
+	canonical map[string]string
+	variants  map[string]map[string]struct{}
+}
+
+func (r *recipeNormalizer) apply(item *models.RecipeDelivery) bool {
+	k := NormalizeRecipeName(item.Recipe)
+	name, ok := r.canonical[k]
+	if !ok {
+		name = strings.Join(strings.Fields(item.Recipe), " ")
+		r.canonical[k] = name
+	}
+	vs := r.variants[name]
+	if vs == nil {
+		vs = make(map[string]struct{})
+		r.variants[name] = vs
+	}
+	vs[item.Recipe] = struct{}{}
+	item.Recipe = name
+	return true
+}
+
+func (r *recipeNormalizer) fillReport(rep *RecipeProcessorReport) {
+	merged := make([]mergedRecipe, 0)
+	for name, vs := range r.variants {
+		if _, same := vs[name]; same && len(vs) == 1 {
+			continue
+		}
+		m := mergedRecipe{Recipe: name, Variants: make([]string, 0, len(vs))}
+		for v := range vs {
+			m.Variants = append(m.Variants, v)
+		}
+		sort.Strings(m.Variants)
+		merged = append(merged, m)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Recipe < merged[j].Recipe
+	})
+	rep.MergedRecipes = merged
+}
@@ -0,0 +1,41 @@
+package processors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert" //nolint:goimports
+	"sber-test/pkg/providers"            //nolint:goimports
+)
+
+func TestNormalizeRecipeName(t *testing.T) {
+	for _, s := range []string{"Tex-Mex Tilapia", "tex-mex tilapia ", "Tex Mex  Tilapia", "ＴＥＸ—ＭＥＸ TILAPIA"} {
+		assert.Equal(t, "tex mex tilapia", NormalizeRecipeName(s), s)
+	}
+	assert.Equal(t, "crème brûlée", NormalizeRecipeName("Crème Brûlée"))
+}
+
+func TestNormalizeRecipes(t *testing.T) {
+	data := providers.RecipeDeliveries{
+		{Recipe: "Tex-Mex Tilapia"},
+		{Recipe: "tex-mex tilapia "},
+		{Recipe: "Tex Mex Tilapia"},
+		{Recipe: "Ink"},
+		{Recipe: "Fajitas"},
+		{Recipe: "Speedy Steak Fajitas"},
+	}
+	proc := NewRecipeReportProcessor(ReportCounterPerRecipe(), ReportUniqueRecipes()).
+		WithStages(NormalizeRecipes(map[string]string{"Fajitas": "Speedy Steak Fajitas"}))
+	report, err := proc.Process(context.Background(), data)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, *report.UniqueRecipeCount)
+	assert.Equal(t, []countPerRecipe{
+		{Recipe: "Ink", Count: 1},
+		{Recipe: "Speedy Steak Fajitas", Count: 2},
+		{Recipe: "Tex-Mex Tilapia", Count: 3},
+	}, report.CountPerRecipe)
+	assert.Equal(t, []mergedRecipe{
+		{Recipe: "Speedy Steak Fajitas", Variants: []string{"Fajitas", "Speedy Steak Fajitas"}},
+		{Recipe: "Tex-Mex Tilapia", Variants: []string{"Tex Mex Tilapia", "Tex-Mex Tilapia", "tex-mex tilapia "}},
+	}, report.MergedRecipes)
+}
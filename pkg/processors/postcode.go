@@ -0,0 +1,141 @@
+package processors
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors" //nolint:goimports
+	"sber-test/pkg/models"  //nolint:goimports
+)
+
+// maxInvalidPostcodes сколько различных невалидных "postcode" перечислять в отчёте
+const maxInvalidPostcodes = 100
+
+type (
+	// PostcodeRule правило нормализации/проверки "postcode": возвращает нормализованное значение
+	// или ошибку, если "postcode" невалиден
+	PostcodeRule func(postcode string) (string, error)
+
+	invalidPostcode struct {
+		Postcode string `json:"postcode"`
+		Reason   string `json:"reason"`
+		Count    int    `json:"count"`
+	}
+
+	invalidPostcodes struct {
+		Count     int               `json:"count"`
+		Postcodes []invalidPostcode `json:"postcodes"`
+	}
+)
+
+// PostcodeTrim убирает пробельные символы по краям
+func PostcodeTrim() PostcodeRule {
+	return func(postcode string) (string, error) {
+		return strings.TrimSpace(postcode), nil
+	}
+}
+
+// PostcodeZeroPad дополняет цифровой "postcode" нулями слева до width символов
+func PostcodeZeroPad(width int) PostcodeRule {
+	return func(postcode string) (string, error) {
+		if len(postcode) >= width || strings.IndexFunc(postcode, notDigit) >= 0 {
+			return postcode, nil
+		}
+		return strings.Repeat("0", width-len(postcode)) + postcode, nil
+	}
+}
+
+// PostcodeFormat проверяет "postcode" регулярным выражением (целиком)
+func PostcodeFormat(re *regexp.Regexp) PostcodeRule {
+	return func(postcode string) (string, error) {
+		if loc := re.FindStringIndex(postcode); loc == nil || loc[0] != 0 || loc[1] != len(postcode) {
+			return postcode, errors.Errorf("does not match '%s'", re)
+		}
+		return postcode, nil
+	}
+}
+
+func notDigit(r rune) bool {
+	return r < '0' || r > '9'
+}
+
+// ValidatePostcodes стадия, применяющая правила к "postcode" по порядку;
+// доставки с невалидным "postcode" отбрасываются и перечисляются в отчёте
+func ValidatePostcodes(rule PostcodeRule, optional ...PostcodeRule) RecipeDeliveryStage {
+	return &postcodeValidator{
+		rules:   append(append([]PostcodeRule(nil), rule), optional...),
+		invalid: make(map[string]*invalidPostcode),
+	}
+}
+
+// RollupPostcodes стадия, заменяющая "postcode" его первыми prefixLen символами,
+// чтобы все отчёты считались по районам
+func RollupPostcodes(prefixLen int) RecipeDeliveryStage {
+	return &postcodeRollup{prefixLen: prefixLen}
+}
+
+// ---------------------------------------- IMPL -------------------------------------
+
+type postcodeValidator struct {
+	rules   []PostcodeRule
+	count   int
+	invalid map[string]*invalidPostcode
+}
+
+func (r *postcodeValidator) apply(item *models.RecipeDelivery) bool {
+	postcode := item.Postcode
+	for _, rule := range r.rules {
+		var e error
+		if postcode, e = rule(postcode); e != nil {
+			r.count++
+			inv := r.invalid[item.Postcode]
+			if inv == nil && len(r.invalid) < maxInvalidPostcodes {
+				inv = &invalidPostcode{Postcode: item.Postcode, Reason: e.Error()}
+				r.invalid[item.Postcode] = inv
+			}
+			if inv != nil {
+				inv.Count++
+			}
+			return false
+		}
+	}
+	item.Postcode = postcode
+	return true
+}
+
+func (r *postcodeValidator) fillReport(rep *RecipeProcessorReport) {
+	ret := &invalidPostcodes{
+		Count:     r.count,
+		Postcodes: make([]invalidPostcode, 0, len(r.invalid)),
+	}
+	for _, inv := range r.invalid {
+		ret.Postcodes = append(ret.Postcodes, *inv)
+	}
+	sort.Slice(ret.Postcodes, func(i, j int) bool {
+		return ret.Postcodes[i].Postcode < ret.Postcodes[j].Postcode
+	})
+	rep.InvalidPostcodes = ret
+}
+
+type postcodeRollup struct {
+	prefixLen int
+}
+
+func (r *postcodeRollup) apply(item *models.RecipeDelivery) bool {
+	// префикс считается в символах, а не в байтах: не разрезать многобайтовый символ
+	n := 0
+	for i := range item.Postcode {
+		if n == r.prefixLen {
+			if len(item.OriginPostcode) == 0 {
+				item.OriginPostcode = item.Postcode
+			}
+			item.Postcode = item.Postcode[:i]
+			break
+		}
+		n++
+	}
+	return true
+}
+
+func (r *postcodeRollup) fillReport(*RecipeProcessorReport) {}
@@ -0,0 +1,62 @@
+package processors
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert" //nolint:goimports
+	"sber-test/pkg/providers"            //nolint:goimports
+)
+
+func TestValidatePostcodes(t *testing.T) {
+	data := providers.RecipeDeliveries{
+		{Recipe: "Ink", Postcode: " 10120"},
+		{Recipe: "Ink", Postcode: "120"},
+		{Recipe: "Ink", Postcode: "abc"},
+		{Recipe: "Ink", Postcode: "abc"},
+		{Recipe: "Ink", Postcode: "10120"},
+		{Recipe: "Ink", Postcode: "10163"},
+	}
+	proc := NewRecipeReportProcessor(mustGroupBy(t, DimPostcode)).WithStages(
+		ValidatePostcodes(PostcodeTrim(), PostcodeZeroPad(5), PostcodeFormat(regexp.MustCompile(`\d{5}`))),
+	)
+	report, err := proc.Process(context.Background(), data)
+	assert.NoError(t, err)
+	assert.Equal(t, []aggregationRow{
+		{Key: []string{"00120"}, Count: 1},
+		{Key: []string{"10120"}, Count: 2},
+		{Key: []string{"10163"}, Count: 1},
+	}, report.Aggregations[0].Rows)
+	assert.NotNil(t, report.InvalidPostcodes)
+	assert.Equal(t, 2, report.InvalidPostcodes.Count)
+	assert.Equal(t, "abc", report.InvalidPostcodes.Postcodes[0].Postcode)
+	assert.Equal(t, 2, report.InvalidPostcodes.Postcodes[0].Count)
+}
+
+func TestRollupPostcodes(t *testing.T) {
+	data := providers.RecipeDeliveries{
+		{Recipe: "Ink", Postcode: "10120"},
+		{Recipe: "Ink", Postcode: "10163"},
+		{Recipe: "Ink", Postcode: "10208"},
+		{Recipe: "Ink", Postcode: "12"},
+		{Recipe: "Ink", Postcode: "ÅÄÖ12"},
+	}
+	proc := NewRecipeReportProcessor(mustGroupBy(t, DimPostcode)).WithStages(RollupPostcodes(3))
+	report, err := proc.Process(context.Background(), data)
+	assert.NoError(t, err)
+	assert.Equal(t, []aggregationRow{
+		{Key: []string{"101"}, Count: 2},
+		{Key: []string{"102"}, Count: 1},
+		{Key: []string{"12"}, Count: 1},
+		{Key: []string{"ÅÄÖ"}, Count: 1},
+	}, report.Aggregations[0].Rows)
+	assert.Nil(t, report.InvalidPostcodes)
+}
+
+// mustGroupBy ...
+func mustGroupBy(t *testing.T, by ...Dimension) RecipeReportSubj {
+	subj, err := ReportGroupBy(GroupBySpec{By: by})
+	assert.NoError(t, err)
+	return subj
+}
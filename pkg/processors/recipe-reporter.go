@@ -14,6 +14,7 @@ import (
 /*//
 {
     "unique_recipe_count": 15,
+    "unique_recipe_count_error": 1,
     "count_per_recipe": [
         {
             "recipe": "Mediterranean Baked Veggies",
@@ -40,6 +41,15 @@ import (
     },
     "match_by_name": [
         "Mediterranean Baked Veggies", "Speedy Steak Fajitas", "Tex-Mex Tilapia"
+    ],
+    "aggregations": [
+        {
+            "group_by": ["postcode", "weekday"],
+            "count_distinct": "recipe",
+            "rows": [
+                {"key": ["10120", "Monday"], "count": 20, "distinct": 7}
+            ]
+        }
     ]
 }
 */
@@ -51,8 +61,9 @@ type (
 	}
 
 	busiestPostcode struct {
-		Postcode      string `json:"postcode"`
-		DeliveryCount int    `json:"delivery_count"`
+		Postcode           string `json:"postcode"`
+		DeliveryCount      int    `json:"delivery_count"`
+		DeliveryCountError *int   `json:"delivery_count_error,omitempty"`
 	}
 
 	countPerPostcodeAndTime struct {
@@ -65,14 +76,24 @@ type (
 	// RecipeProcessorReport отчёт
 	RecipeProcessorReport struct {
 		UniqueRecipeCount       *int                     `json:"unique_recipe_count,omitempty"`
+		UniqueRecipeCountError  *int                     `json:"unique_recipe_count_error,omitempty"`
 		CountPerRecipe          []countPerRecipe         `json:"count_per_recipe,omitempty"`
 		BusiestPostcode         *busiestPostcode         `json:"busiest_postcode,omitempty"`
 		CountPerPostcodeAndTime *countPerPostcodeAndTime `json:"count_per_postcode_and_time,omitempty"`
 		RecipesMatchedByName    []string                 `json:"match_by_name,omitempty"`
+		Aggregations            []aggregation            `json:"aggregations,omitempty"`
+		MergedRecipes           []mergedRecipe           `json:"merged_recipes,omitempty"`
+		InvalidPostcodes        *invalidPostcodes        `json:"invalid_postcodes,omitempty"`
+		UnmappedPostcodes       *unmappedPostcodes       `json:"unmapped_postcodes,omitempty"`
+		CountPerCategory        []countPerCategory       `json:"count_per_category,omitempty"`
+		CountPerTag             []countPerTag            `json:"count_per_tag,omitempty"`
+		TagShares               []tagShare               `json:"tag_share,omitempty"`
+		UnknownRecipes          *unknownRecipes          `json:"unknown_recipes,omitempty"`
 	}
 
 	// RecipeReportProcessor тот кто нам отчёт сделает
 	RecipeReportProcessor struct {
+		stages    []RecipeDeliveryStage
 		reporters []RecipeReportSubj
 	}
 
@@ -81,6 +102,13 @@ type (
 		consume(models.RecipeDelivery)
 		fillReport(*RecipeProcessorReport)
 	}
+
+	// RecipeDeliveryStage предобработка доставки до того, как её получат subjects;
+	// apply может изменить доставку или отбросить её, вернув false
+	RecipeDeliveryStage interface {
+		apply(*models.RecipeDelivery) bool
+		fillReport(*RecipeProcessorReport)
+	}
 )
 
 // ReportUniqueRecipes Подсчитать число уникальных "recipe name"
@@ -91,7 +119,7 @@ func ReportUniqueRecipes() RecipeReportSubj {
 // ReportBusiestPostcode Найти "postcode" с наибольшим числом доаставок
 func ReportBusiestPostcode() RecipeReportSubj {
 	return &busiestPostcodeReporter{
-		postalCodeCounter: make(map[string]map[ts.Delivery]struct{}),
+		postalCodeCounter: make(map[string]map[postcodeDelivery]struct{}),
 	}
 }
 
@@ -125,20 +153,39 @@ func NewRecipeReportProcessor(r RecipeReportSubj, optional ...RecipeReportSubj)
 	return ret
 }
 
+// WithStages добавляет стадии предобработки; стадии применяются в порядке добавления
+func (rp *RecipeReportProcessor) WithStages(stages ...RecipeDeliveryStage) *RecipeReportProcessor {
+	rp.stages = append(rp.stages, stages...)
+	return rp
+}
+
 // Process обработаеи и получим-ка отчётец
 func (rp *RecipeReportProcessor) Process(ctx context.Context, provider providers.RecipeDeliveryProvider) (RecipeProcessorReport, error) {
 	const api = "RecipeProcessor.Process"
 
 	var report RecipeProcessorReport
-	err := provider.Provide(ctx, func(delivery models.RecipeDelivery) error {
-		for _, rep := range rp.reporters {
-			rep.consume(delivery)
-		}
-		return nil
-	})
-	if err != nil {
+	if err := ctx.Err(); err != nil {
 		return report, errors.Wrap(err, api)
 	}
+	if !rp.processIndexed(provider) {
+		err := provider.Provide(ctx, func(delivery models.RecipeDelivery) error {
+			for _, st := range rp.stages {
+				if !st.apply(&delivery) {
+					return nil
+				}
+			}
+			for _, rep := range rp.reporters {
+				rep.consume(delivery)
+			}
+			return nil
+		})
+		if err != nil {
+			return report, errors.Wrap(err, api)
+		}
+	}
+	for _, st := range rp.stages {
+		st.fillReport(&report)
+	}
 	for _, rep := range rp.reporters {
 		rep.fillReport(&report)
 	}
@@ -179,13 +226,11 @@ func (r *recipeMatchByName) fillReport(rep *RecipeProcessorReport) {
 	for s := range r.res {
 		res = append(res, s)
 	}
-	if len(res) > 0 {
-		sort.Slice(res, func(i, j int) bool {
-			l, r := res[i], res[j]
-			return strings.Compare(l, r) < 0
-		})
-		rep.RecipesMatchedByName = res
-	}
+	sort.Slice(res, func(i, j int) bool {
+		l, r := res[i], res[j]
+		return strings.Compare(l, r) < 0
+	})
+	rep.RecipesMatchedByName = res
 }
 
 type counterPerRecipe struct {
@@ -201,32 +246,41 @@ func (r *counterPerRecipe) fillReport(rep *RecipeProcessorReport) {
 	for name, c := range r.counter {
 		items = append(items, countPerRecipe{Recipe: name, Count: c})
 	}
-	if len(items) > 0 {
-		sort.Slice(items, func(i, j int) bool {
-			l, r := items[i], items[j]
-			return strings.Compare(l.Recipe, r.Recipe) < 0
-		})
-		rep.CountPerRecipe = items
+	sort.Slice(items, func(i, j int) bool {
+		l, r := items[i], items[j]
+		return strings.Compare(l.Recipe, r.Recipe) < 0
+	})
+	rep.CountPerRecipe = items
+}
+
+// postcodeDelivery доставка по одному адресу: рецепты в одном "postcode" и одном окне приезжают вместе
+type postcodeDelivery struct {
+	postcode string
+	delivery ts.Delivery
+}
+
+func postcodeDeliveryOf(item models.RecipeDelivery) postcodeDelivery {
+	ret := postcodeDelivery{postcode: item.OriginPostcode, delivery: item.Delivery}
+	if len(ret.postcode) == 0 {
+		ret.postcode = item.Postcode
 	}
+	return ret
 }
 
 type busiestPostcodeReporter struct {
-	postalCodeCounter map[string]map[ts.Delivery]struct{}
+	postalCodeCounter map[string]map[postcodeDelivery]struct{}
 }
 
 func (r *busiestPostcodeReporter) consume(item models.RecipeDelivery) {
 	counter := r.postalCodeCounter[item.Postcode]
 	if counter == nil {
-		counter = make(map[ts.Delivery]struct{})
+		counter = make(map[postcodeDelivery]struct{})
 		r.postalCodeCounter[item.Postcode] = counter
 	}
-	counter[item.Delivery] = struct{}{}
+	counter[postcodeDeliveryOf(item)] = struct{}{}
 }
 
 func (r *busiestPostcodeReporter) fillReport(rep *RecipeProcessorReport) {
-	if len(r.postalCodeCounter) == 0 {
-		return
-	}
 	var busiest busiestPostcode
 	first := true
 	for p, c := range r.postalCodeCounter {
@@ -239,9 +293,7 @@ func (r *busiestPostcodeReporter) fillReport(rep *RecipeProcessorReport) {
 			busiest.DeliveryCount = len(c)
 		}
 	}
-	if len(busiest.Postcode) > 0 {
-		rep.BusiestPostcode = &busiest
-	}
+	rep.BusiestPostcode = &busiest
 }
 
 type counterPerPostcodeAndTime struct {
@@ -258,7 +310,6 @@ func (r *counterPerPostcodeAndTime) consume(item models.RecipeDelivery) {
 }
 
 func (r *counterPerPostcodeAndTime) fillReport(rep *RecipeProcessorReport) {
-	if r.DeliveryCount > 0 {
-		rep.CountPerPostcodeAndTime = &r.countPerPostcodeAndTime
-	}
+	ret := r.countPerPostcodeAndTime
+	rep.CountPerPostcodeAndTime = &ret
 }
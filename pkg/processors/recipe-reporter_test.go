@@ -115,3 +115,31 @@ func TestReportDeliveryCountForPostcodeAndTime(t *testing.T) {
 	assert.Equal(t, "1", report.CountPerPostcodeAndTime.Postcode)
 	assert.Equal(t, 2, report.CountPerPostcodeAndTime.DeliveryCount)
 }
+
+func TestReportApproximate(t *testing.T) {
+	data := []models.RecipeDelivery{
+		{Recipe: "Ink", Postcode: "1", Delivery: ts.ConstructDelivery(time.Monday, 10, 15)},
+		{Recipe: "Ink", Postcode: "1", Delivery: ts.ConstructDelivery(time.Thursday, 10, 15)},
+		{Recipe: "B Potato", Postcode: "1", Delivery: ts.ConstructDelivery(time.Thursday, 10, 15)},
+		{Recipe: "Ink", Postcode: "1", Delivery: ts.ConstructDelivery(time.Thursday, 18, 22)},
+		{Recipe: "B Potato", Postcode: "2", Delivery: ts.ConstructDelivery(time.Wednesday, 8, 15)},
+		{Recipe: "A Veggie", Postcode: "2", Delivery: ts.ConstructDelivery(time.Saturday, 11, 20)},
+		{Recipe: "C Mushroom", Postcode: "4", Delivery: ts.ConstructDelivery(time.Saturday, 11, 20)},
+	}
+	unique := ReportUniqueRecipesApprox(0.01)
+	busiest := ReportBusiestPostcodeApprox(0.01, 1000)
+	for _, item := range data {
+		unique.consume(item)
+		busiest.consume(item)
+	}
+	var report RecipeProcessorReport
+	unique.fillReport(&report)
+	busiest.fillReport(&report)
+	assert.NotNil(t, report.UniqueRecipeCount)
+	assert.Equal(t, 4, *report.UniqueRecipeCount)
+	assert.NotNil(t, report.UniqueRecipeCountError)
+	assert.NotNil(t, report.BusiestPostcode)
+	assert.Equal(t, "1", report.BusiestPostcode.Postcode)
+	assert.Equal(t, 3, report.BusiestPostcode.DeliveryCount)
+	assert.NotNil(t, report.BusiestPostcode.DeliveryCountError)
+}
@@ -0,0 +1,99 @@
+package processors
+
+import (
+	"sort"
+
+	"github.com/pkg/errors" //nolint:goimports
+	"sber-test/pkg/models"  //nolint:goimports
+)
+
+// maxUnmappedPostcodes сколько различных "postcode" без зоны перечислять в отчёте
+const maxUnmappedPostcodes = 100
+
+type (
+	unmappedPostcode struct {
+		Postcode string `json:"postcode"`
+		Count    int    `json:"count"`
+	}
+
+	unmappedPostcodes struct {
+		Count     int                `json:"count"`
+		Postcodes []unmappedPostcode `json:"postcodes"`
+	}
+)
+
+// EnrichRegions стадия, дополняющая доставку зоной из regions ("postcode" -> зона).
+// Если rollup задан (DimZone, DimCity или DimDepot), "postcode" заменяется соответствующим
+// атрибутом зоны, и все отчёты по "postcode" считаются по зонам; доставки без зоны
+// в этом случае в отчёты не попадают. "postcode" без зоны перечисляются в отчёте
+func EnrichRegions(regions map[string]models.Region, rollup Dimension) (RecipeDeliveryStage, error) {
+	const api = "EnrichRegions"
+
+	switch rollup {
+	case DimNone, DimZone, DimCity, DimDepot:
+	default:
+		return nil, errors.Errorf("%s: can't roll up postcodes by '%s'", api, rollup)
+	}
+	ret := &regionEnricher{
+		regions:  make(map[string]*models.Region, len(regions)),
+		rollup:   rollup,
+		unmapped: make(map[string]int),
+	}
+	for postcode := range regions {
+		region := regions[postcode]
+		ret.regions[postcode] = &region
+	}
+	return ret, nil
+}
+
+// HasRegions среди стадий есть EnrichRegions
+func HasRegions(stages ...RecipeDeliveryStage) bool {
+	for _, s := range stages {
+		if _, ok := s.(*regionEnricher); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ---------------------------------------- IMPL -------------------------------------
+
+type regionEnricher struct {
+	regions  map[string]*models.Region
+	rollup   Dimension
+	count    int
+	unmapped map[string]int
+}
+
+func (r *regionEnricher) apply(item *models.RecipeDelivery) bool {
+	region := r.regions[item.Postcode]
+	item.Region = region
+	if region == nil {
+		r.count++
+		if _, ok := r.unmapped[item.Postcode]; ok || len(r.unmapped) < maxUnmappedPostcodes {
+			r.unmapped[item.Postcode]++
+		}
+		return r.rollup == DimNone
+	}
+	if r.rollup != DimNone {
+		if len(item.OriginPostcode) == 0 {
+			item.OriginPostcode = item.Postcode
+		}
+		item.Postcode = r.rollup.Value(*item)
+	}
+	return true
+}
+
+func (r *regionEnricher) fillReport(rep *RecipeProcessorReport) {
+	ret := &unmappedPostcodes{
+		Count:     r.count,
+		Postcodes: make([]unmappedPostcode, 0, len(r.unmapped)),
+	}
+	for postcode, n := range r.unmapped {
+		ret.Postcodes = append(ret.Postcodes, unmappedPostcode{Postcode: postcode, Count: n})
+	}
+	sort.Slice(ret.Postcodes, func(i, j int) bool {
+		return ret.Postcodes[i].Postcode < ret.Postcodes[j].Postcode
+	})
+	rep.UnmappedPostcodes = ret
+}
@@ -0,0 +1,70 @@
+package processors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" //nolint:goimports
+	"sber-test/pkg/models"
+	"sber-test/pkg/providers"
+	ts "sber-test/pkg/time-slot" //nolint:goimports
+)
+
+var testRegions = map[string]models.Region{
+	"10120": {Zone: "North", City: "Berlin", Depot: "B1"},
+	"10163": {Zone: "North", City: "Berlin", Depot: "B2"},
+	"20095": {Zone: "West", City: "Hamburg", Depot: "H1"},
+}
+
+var regionData = providers.RecipeDeliveries{
+	{Recipe: "Ink", Postcode: "10120", Delivery: ts.ConstructDelivery(time.Monday, 10, 15)},
+	{Recipe: "Ink", Postcode: "10163", Delivery: ts.ConstructDelivery(time.Monday, 10, 15)},
+	{Recipe: "Ink", Postcode: "10163", Delivery: ts.ConstructDelivery(time.Friday, 10, 15)},
+	{Recipe: "B Potato", Postcode: "20095", Delivery: ts.ConstructDelivery(time.Monday, 8, 15)},
+	{Recipe: "A Veggie", Postcode: "99999", Delivery: ts.ConstructDelivery(time.Monday, 11, 20)},
+}
+
+func TestEnrichRegionsGroupBy(t *testing.T) {
+	stage, err := EnrichRegions(testRegions, DimNone)
+	assert.NoError(t, err)
+	proc := NewRecipeReportProcessor(mustGroupBy(t, DimZone), ReportBusiestPostcode()).WithStages(stage)
+	report, err := proc.Process(context.Background(), regionData)
+	assert.NoError(t, err)
+	assert.Equal(t, []aggregationRow{
+		{Key: []string{""}, Count: 1},
+		{Key: []string{"North"}, Count: 3},
+		{Key: []string{"West"}, Count: 1},
+	}, report.Aggregations[0].Rows)
+	assert.Equal(t, "10163", report.BusiestPostcode.Postcode)
+	assert.Equal(t, &unmappedPostcodes{
+		Count:     1,
+		Postcodes: []unmappedPostcode{{Postcode: "99999", Count: 1}},
+	}, report.UnmappedPostcodes)
+}
+
+func TestEnrichRegionsRollup(t *testing.T) {
+	stage, err := EnrichRegions(testRegions, DimCity)
+	assert.NoError(t, err)
+	proc := NewRecipeReportProcessor(
+		ReportBusiestPostcode(),
+		ReportDeliveryCountForPostcodeAndTime("Hamburg", ts.Hour(6), ts.Hour(18)),
+	).WithStages(stage)
+	report, err := proc.Process(context.Background(), regionData)
+	assert.NoError(t, err)
+	assert.Equal(t, "Berlin", report.BusiestPostcode.Postcode)
+	assert.Equal(t, 3, report.BusiestPostcode.DeliveryCount)
+	assert.Equal(t, 1, report.CountPerPostcodeAndTime.DeliveryCount)
+	assert.Equal(t, 1, report.UnmappedPostcodes.Count)
+
+	_, err = EnrichRegions(testRegions, DimRecipe)
+	assert.Error(t, err)
+}
+
+func TestEnrichRegionsMiss(t *testing.T) {
+	stage, err := EnrichRegions(testRegions, DimNone)
+	assert.NoError(t, err)
+	item := models.RecipeDelivery{Postcode: "99999", Region: &models.Region{Zone: "Forged"}}
+	assert.True(t, stage.apply(&item))
+	assert.Nil(t, item.Region)
+}
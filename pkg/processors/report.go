@@ -0,0 +1,93 @@
+package processors
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// MarshalJSON выводит все запрошенные разделы отчёта, даже нулевые и пустые: пропускаются только
+// разделы, которые никто не заполнял (nil); encoding/json с omitempty пропустил бы и пустые списки
+func (r RecipeProcessorReport) MarshalJSON() ([]byte, error) {
+	v := reflect.ValueOf(r)
+	t := v.Type()
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.SplitN(t.Field(i).Tag.Get("json"), ",", 2)[0]
+		if name == "-" || !t.Field(i).IsExported() {
+			continue
+		}
+		f := v.Field(i)
+		switch f.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+			if f.IsNil() {
+				continue
+			}
+		}
+		data, err := json.Marshal(f.Interface())
+		if err != nil {
+			return nil, err
+		}
+		if b.Len() > 1 {
+			b.WriteByte(',')
+		}
+		if len(name) == 0 {
+			name = t.Field(i).Name
+		}
+		b.WriteString(`"` + name + `":`)
+		b.Write(data)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// OmitEmpty копия отчёта без нулевых и пустых разделов: пустых списков, нулевых счётчиков,
+// busiest_postcode и tag_share без доставок
+func (r RecipeProcessorReport) OmitEmpty() RecipeProcessorReport {
+	if r.UniqueRecipeCount != nil && *r.UniqueRecipeCount == 0 {
+		r.UniqueRecipeCount, r.UniqueRecipeCountError = nil, nil
+	}
+	if len(r.CountPerRecipe) == 0 {
+		r.CountPerRecipe = nil
+	}
+	if r.BusiestPostcode != nil && r.BusiestPostcode.DeliveryCount == 0 {
+		r.BusiestPostcode = nil
+	}
+	if r.CountPerPostcodeAndTime != nil && r.CountPerPostcodeAndTime.DeliveryCount == 0 {
+		r.CountPerPostcodeAndTime = nil
+	}
+	if len(r.RecipesMatchedByName) == 0 {
+		r.RecipesMatchedByName = nil
+	}
+	var aggs []aggregation
+	for _, agg := range r.Aggregations {
+		if len(agg.Rows) > 0 {
+			aggs = append(aggs, agg)
+		}
+	}
+	r.Aggregations = aggs
+	if len(r.MergedRecipes) == 0 {
+		r.MergedRecipes = nil
+	}
+	if r.InvalidPostcodes != nil && r.InvalidPostcodes.Count == 0 {
+		r.InvalidPostcodes = nil
+	}
+	if r.UnmappedPostcodes != nil && r.UnmappedPostcodes.Count == 0 {
+		r.UnmappedPostcodes = nil
+	}
+	if len(r.CountPerCategory) == 0 {
+		r.CountPerCategory = nil
+	}
+	if len(r.CountPerTag) == 0 {
+		r.CountPerTag = nil
+	}
+	if len(r.TagShares) == 0 || r.TagShares[0].TotalCount == 0 {
+		r.TagShares = nil
+	}
+	if r.UnknownRecipes != nil && r.UnknownRecipes.Count == 0 {
+		r.UnknownRecipes = nil
+	}
+	return r
+}
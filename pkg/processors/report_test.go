@@ -0,0 +1,79 @@
+package processors
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" //nolint:goimports
+	"sber-test/pkg/models"
+	"sber-test/pkg/providers"
+	ts "sber-test/pkg/time-slot" //nolint:goimports
+)
+
+func emptyResultProcessor(t *testing.T) *RecipeReportProcessor {
+	agg, err := ReportGroupBy(GroupBySpec{By: []Dimension{DimPostcode}})
+	assert.NoError(t, err)
+	regions, err := EnrichRegions(map[string]models.Region{}, DimNone)
+	assert.NoError(t, err)
+	catalog, err := JoinCatalog(nil)
+	assert.NoError(t, err)
+	return NewRecipeReportProcessor(
+		ReportUniqueRecipes(), ReportCounterPerRecipe(), ReportBusiestPostcode(),
+		ReportDeliveryCountForPostcodeAndTime("10120", ts.Hour(10), ts.Hour(15)),
+		ReportIfMatchedRecipes("Nothing"), agg,
+		ReportCountPerCategory(), ReportCountPerTag(), ReportTagShare("vegan"),
+	).WithStages(
+		NormalizeRecipes(nil), ValidatePostcodes(PostcodeFormat(regexp.MustCompile(`^[0-9]+$`))), regions, catalog,
+	)
+}
+
+func TestReportExplicitZeros(t *testing.T) {
+	report, err := emptyResultProcessor(t).Process(context.Background(), providers.RecipeDeliveries(nil))
+	assert.NoError(t, err)
+	data, err := json.Marshal(report)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"unique_recipe_count": 0,
+		"count_per_recipe": [],
+		"busiest_postcode": {"postcode": "", "delivery_count": 0},
+		"count_per_postcode_and_time": {"postcode": "10120", "from": "10AM", "to": "3PM", "delivery_count": 0},
+		"match_by_name": [],
+		"aggregations": [{"group_by": ["postcode"], "rows": []}],
+		"merged_recipes": [],
+		"invalid_postcodes": {"count": 0, "postcodes": []},
+		"unmapped_postcodes": {"count": 0, "postcodes": []},
+		"count_per_category": [],
+		"count_per_tag": [],
+		"tag_share": [{"tag": "vegan", "delivery_count": 0, "total_count": 0, "share": 0}],
+		"unknown_recipes": {"count": 0, "recipes": []}
+	}`, string(data))
+
+	data, err = json.Marshal(report.OmitEmpty())
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(data))
+
+	data, err = json.Marshal(RecipeProcessorReport{})
+	assert.NoError(t, err)
+	assert.Equal(t, `{}`, string(data))
+}
+
+func TestReportOmitEmptyKeepsResults(t *testing.T) {
+	report, err := emptyResultProcessor(t).Process(context.Background(), providers.RecipeDeliveries{
+		{Recipe: "Ink", Postcode: "10120", Delivery: ts.ConstructDelivery(time.Monday, 11, 14)},
+	})
+	assert.NoError(t, err)
+	omitted := report.OmitEmpty()
+	assert.Equal(t, report.CountPerRecipe, omitted.CountPerRecipe)
+	assert.Equal(t, report.BusiestPostcode, omitted.BusiestPostcode)
+	assert.Equal(t, report.CountPerPostcodeAndTime, omitted.CountPerPostcodeAndTime)
+	assert.Equal(t, report.UnmappedPostcodes, omitted.UnmappedPostcodes)
+	assert.Equal(t, report.UnknownRecipes, omitted.UnknownRecipes)
+	assert.Equal(t, report.TagShares, omitted.TagShares)
+	assert.Nil(t, omitted.RecipesMatchedByName)
+	assert.Nil(t, omitted.InvalidPostcodes)
+	assert.Nil(t, omitted.MergedRecipes)
+	assert.Nil(t, omitted.CountPerCategory)
+}
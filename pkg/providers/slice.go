@@ -0,0 +1,33 @@
+package providers
+
+import (
+	"context"
+
+	"sber-test/pkg/models"
+)
+
+// RecipeDeliveries доставки, загруженные в память
+type RecipeDeliveries []models.RecipeDelivery
+
+// Provide ...
+func (p RecipeDeliveries) Provide(ctx context.Context, consumer func(models.RecipeDelivery) error) error {
+	for _, item := range p {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := consumer(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Collect читает все доставки провайдера в память
+func Collect(ctx context.Context, p RecipeDeliveryProvider) (RecipeDeliveries, error) {
+	var ret RecipeDeliveries
+	err := p.Provide(ctx, func(item models.RecipeDelivery) error {
+		ret = append(ret, item)
+		return nil
+	})
+	return ret, err
+}
@@ -0,0 +1,92 @@
+package query
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+type tokenKind int
+
+const (
+	tkEOF tokenKind = iota
+	tkIdent
+	tkString
+	tkNumber
+	tkSymbol
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) is(kw string) bool {
+	return t.kind == tkIdent && strings.EqualFold(t.text, kw)
+}
+
+func (t token) isSymbol(s string) bool {
+	return t.kind == tkSymbol && t.text == s
+}
+
+func tokenize(src string) ([]token, error) {
+	var ret []token
+	rs := []rune(src)
+	for i := 0; i < len(rs); {
+		c := rs[i]
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '\'':
+			var b strings.Builder
+			start := i
+			for i++; ; i++ {
+				if i >= len(rs) {
+					return nil, errors.Errorf("unterminated string at %d", start)
+				}
+				if rs[i] == '\'' {
+					if i+1 < len(rs) && rs[i+1] == '\'' {
+						b.WriteRune('\'')
+						i++
+						continue
+					}
+					i++
+					break
+				}
+				b.WriteRune(rs[i])
+			}
+			ret = append(ret, token{kind: tkString, text: b.String(), pos: start})
+		case unicode.IsDigit(c):
+			start := i
+			for i < len(rs) && unicode.IsDigit(rs[i]) {
+				i++
+			}
+			ret = append(ret, token{kind: tkNumber, text: string(rs[start:i]), pos: start})
+		case unicode.IsLetter(c) || c == '_':
+			start := i
+			for i < len(rs) && (unicode.IsLetter(rs[i]) || unicode.IsDigit(rs[i]) || rs[i] == '_') {
+				i++
+			}
+			ret = append(ret, token{kind: tkIdent, text: string(rs[start:i]), pos: start})
+		default:
+			start := i
+			sym := string(c)
+			if i+1 < len(rs) {
+				switch two := string(rs[i : i+2]); two {
+				case "<=", ">=", "<>", "!=":
+					sym = two
+				}
+			}
+			switch sym {
+			case ",", "(", ")", "*", "=", "<", ">", ";", "<=", ">=", "<>", "!=":
+			default:
+				return nil, errors.Errorf("unexpected '%s' at %d", sym, start)
+			}
+			i += len([]rune(sym))
+			ret = append(ret, token{kind: tkSymbol, text: sym, pos: start})
+		}
+	}
+	return append(ret, token{kind: tkEOF, pos: len(rs)}), nil
+}
@@ -0,0 +1,573 @@
+package query
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors" //nolint:goimports
+	"sber-test/pkg/models"
+	"sber-test/pkg/processors"
+	ts "sber-test/pkg/time-slot" //nolint:goimports
+)
+
+// Table единственная таблица, к которой можно обращаться в запросах
+const Table = "deliveries"
+
+// Query скомпилированный запрос
+type Query struct {
+	// Filter условие WHERE; nil если условия нет
+	Filter processors.RecipeDeliveryPredicate
+	// Spec агрегация, заданная SELECT/GROUP BY/ORDER BY/LIMIT
+	Spec processors.GroupBySpec
+
+	regions bool
+}
+
+// Compile компилирует запрос вида
+//
+//	SELECT postcode, COUNT(*) FROM deliveries WHERE weekday='Monday' GROUP BY postcode ORDER BY 2 DESC LIMIT 10
+func Compile(src string) (*Query, error) {
+	const api = "query.Compile"
+
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, errors.Wrap(err, api)
+	}
+	p := parser{tokens: tokens}
+	var q *Query
+	if q, err = p.parseQuery(); err != nil {
+		return nil, errors.Wrap(err, api)
+	}
+	return q, nil
+}
+
+// NeedsRegions запрос обращается к измерениям зоны доставки (zone, city, depot)
+func (q *Query) NeedsRegions() bool {
+	return q.regions
+}
+
+// Subject subject для RecipeReportProcessor, выполняющий запрос
+func (q *Query) Subject() (processors.RecipeReportSubj, error) {
+	subj, err := processors.ReportGroupBy(q.Spec)
+	if err != nil {
+		return nil, err
+	}
+	if q.Filter != nil {
+		subj = processors.ReportFiltered(q.Filter, subj)
+	}
+	return subj, nil
+}
+
+// ---------------------------------------- IMPL -------------------------------------
+
+type selectItem struct {
+	dim      processors.Dimension
+	count    bool
+	distinct bool
+}
+
+type parser struct {
+	tokens  []token
+	pos     int
+	regions bool
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tkEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) unexpected(t token) error {
+	if t.kind == tkEOF {
+		return errors.New("unexpected end of query")
+	}
+	return errors.Errorf("unexpected '%s' at %d", t.text, t.pos)
+}
+
+func (p *parser) expectKeyword(kw string) error {
+	if t := p.next(); !t.is(kw) {
+		return errors.Wrapf(p.unexpected(t), "expected %s", strings.ToUpper(kw))
+	}
+	return nil
+}
+
+func (p *parser) expectSymbol(s string) error {
+	if t := p.next(); !t.isSymbol(s) {
+		return errors.Wrapf(p.unexpected(t), "expected '%s'", s)
+	}
+	return nil
+}
+
+func (p *parser) parseDimension() (processors.Dimension, error) {
+	t := p.next()
+	if t.kind != tkIdent {
+		return processors.DimNone, p.unexpected(t)
+	}
+	d, err := processors.ParseDimension(t.text)
+	p.regions = p.regions || d.IsRegion()
+	return d, err
+}
+
+func (p *parser) parseQuery() (*Query, error) {
+	if err := p.expectKeyword("select"); err != nil {
+		return nil, err
+	}
+	items, err := p.parseSelectList()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expectKeyword("from"); err != nil {
+		return nil, err
+	}
+	if t := p.next(); t.kind != tkIdent || !strings.EqualFold(t.text, Table) {
+		return nil, errors.Errorf("unknown table '%s'", t.text)
+	}
+	q := new(Query)
+	if p.peek().is("where") {
+		p.next()
+		if q.Filter, err = p.parseOr(); err != nil {
+			return nil, err
+		}
+	}
+	var groupBy []processors.Dimension
+	if p.peek().is("group") {
+		p.next()
+		if err = p.expectKeyword("by"); err != nil {
+			return nil, err
+		}
+		for {
+			var d processors.Dimension
+			if d, err = p.parseDimension(); err != nil {
+				return nil, err
+			}
+			groupBy = append(groupBy, d)
+			if !p.peek().isSymbol(",") {
+				break
+			}
+			p.next()
+		}
+	}
+	if err = q.applySelect(items, groupBy); err != nil {
+		return nil, err
+	}
+	if p.peek().is("order") {
+		p.next()
+		if err = p.expectKeyword("by"); err != nil {
+			return nil, err
+		}
+		if q.Spec.OrderBy, err = p.parseOrderList(items); err != nil {
+			return nil, err
+		}
+		if err = q.checkOrder(); err != nil {
+			return nil, err
+		}
+	}
+	if p.peek().is("limit") {
+		p.next()
+		t := p.next()
+		if t.kind != tkNumber {
+			return nil, p.unexpected(t)
+		}
+		if q.Spec.Limit, err = strconv.Atoi(t.text); err != nil {
+			return nil, err
+		}
+	}
+	if p.peek().isSymbol(";") {
+		p.next()
+	}
+	if t := p.next(); t.kind != tkEOF {
+		return nil, p.unexpected(t)
+	}
+	q.regions = p.regions
+	return q, nil
+}
+
+func (p *parser) parseSelectList() ([]selectItem, error) {
+	var items []selectItem
+	for {
+		item, err := p.parseSelectItem()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		if !p.peek().isSymbol(",") {
+			return items, nil
+		}
+		p.next()
+	}
+}
+
+func (p *parser) parseSelectItem() (selectItem, error) {
+	var item selectItem
+	if !p.peek().is("count") {
+		var err error
+		item.dim, err = p.parseDimension()
+		return item, err
+	}
+	p.next()
+	if err := p.expectSymbol("("); err != nil {
+		return item, err
+	}
+	item.count = true
+	if p.peek().isSymbol("*") {
+		p.next()
+	} else {
+		if err := p.expectKeyword("distinct"); err != nil {
+			return item, err
+		}
+		var err error
+		if item.dim, err = p.parseDimension(); err != nil {
+			return item, err
+		}
+		item.distinct = true
+	}
+	return item, p.expectSymbol(")")
+}
+
+func (q *Query) applySelect(items []selectItem, groupBy []processors.Dimension) error {
+	grouped := make(map[processors.Dimension]bool)
+	for _, d := range groupBy {
+		grouped[d] = true
+	}
+	added := make(map[processors.Dimension]bool)
+	for _, item := range items {
+		switch {
+		case item.distinct:
+			if q.Spec.CountDistinct != processors.DimNone && q.Spec.CountDistinct != item.dim {
+				return errors.New("only one COUNT(DISTINCT ...) is supported")
+			}
+			q.Spec.CountDistinct = item.dim
+		case item.count:
+		default:
+			if !grouped[item.dim] {
+				return errors.Errorf("column '%s' must appear in GROUP BY", item.dim)
+			}
+			if !added[item.dim] {
+				added[item.dim] = true
+				q.Spec.By = append(q.Spec.By, item.dim)
+			}
+		}
+	}
+	for _, d := range groupBy {
+		if !added[d] {
+			added[d] = true
+			q.Spec.By = append(q.Spec.By, d)
+		}
+	}
+	return nil
+}
+
+func (p *parser) parseOrderList(items []selectItem) ([]processors.GroupOrder, error) {
+	var ret []processors.GroupOrder
+	for {
+		var o processors.GroupOrder
+		t := p.peek()
+		switch {
+		case t.kind == tkNumber:
+			p.next()
+			n, err := strconv.Atoi(t.text)
+			if err != nil || n < 1 || n > len(items) {
+				return nil, errors.Errorf("ORDER BY position %s is out of select list", t.text)
+			}
+			item := items[n-1]
+			switch {
+			case item.distinct:
+				o.Column = processors.OrderColumnDistinct
+			case item.count:
+				o.Column = processors.OrderColumnCount
+			default:
+				o.Column = item.dim.String()
+			}
+		case t.is("count"):
+			item, err := p.parseSelectItem()
+			if err != nil {
+				return nil, err
+			}
+			o.Column = processors.OrderColumnCount
+			if item.distinct {
+				o.Column = processors.OrderColumnDistinct
+			}
+		default:
+			d, err := p.parseDimension()
+			if err != nil {
+				return nil, err
+			}
+			o.Column = d.String()
+		}
+		if p.peek().is("desc") {
+			p.next()
+			o.Desc = true
+		} else if p.peek().is("asc") {
+			p.next()
+		}
+		ret = append(ret, o)
+		if !p.peek().isSymbol(",") {
+			return ret, nil
+		}
+		p.next()
+	}
+}
+
+func (q *Query) checkOrder() error {
+	for _, o := range q.Spec.OrderBy {
+		switch o.Column {
+		case processors.OrderColumnCount, processors.OrderColumnDistinct:
+			continue
+		}
+		grouped := false
+		for _, d := range q.Spec.By {
+			grouped = grouped || d.String() == o.Column
+		}
+		if !grouped {
+			return errors.Errorf("ORDER BY column '%s' must appear in GROUP BY", o.Column)
+		}
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (processors.RecipeDeliveryPredicate, error) {
+	l, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is("or") {
+		p.next()
+		var r processors.RecipeDeliveryPredicate
+		if r, err = p.parseAnd(); err != nil {
+			return nil, err
+		}
+		l = or(l, r)
+	}
+	return l, nil
+}
+
+func (p *parser) parseAnd() (processors.RecipeDeliveryPredicate, error) {
+	l, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is("and") {
+		p.next()
+		var r processors.RecipeDeliveryPredicate
+		if r, err = p.parseNot(); err != nil {
+			return nil, err
+		}
+		l = and(l, r)
+	}
+	return l, nil
+}
+
+func (p *parser) parseNot() (processors.RecipeDeliveryPredicate, error) {
+	if p.peek().is("not") {
+		p.next()
+		pred, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return not(pred), nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (processors.RecipeDeliveryPredicate, error) {
+	if p.peek().isSymbol("(") {
+		p.next()
+		pred, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return pred, p.expectSymbol(")")
+	}
+	dim, err := p.parseDimension()
+	if err != nil {
+		return nil, err
+	}
+	negate := false
+	if p.peek().is("not") {
+		p.next()
+		negate = true
+	}
+	var pred processors.RecipeDeliveryPredicate
+	t := p.next()
+	switch {
+	case t.is("in"):
+		pred, err = p.parseIn(dim)
+	case t.is("like"):
+		pred, err = p.parseLike(dim)
+	case !negate && t.kind == tkSymbol:
+		pred, err = p.parseComparison(dim, t.text)
+	default:
+		err = p.unexpected(t)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if negate {
+		pred = not(pred)
+	}
+	return pred, nil
+}
+
+func (p *parser) parseLiteral(dim processors.Dimension) (value, error) {
+	t := p.next()
+	if t.kind != tkString && t.kind != tkNumber {
+		return value{}, p.unexpected(t)
+	}
+	return parseValue(dim, t.text)
+}
+
+func (p *parser) parseComparison(dim processors.Dimension, op string) (processors.RecipeDeliveryPredicate, error) {
+	var accept func(int) bool
+	switch op {
+	case "=":
+		accept = func(c int) bool { return c == 0 }
+	case "!=", "<>":
+		accept = func(c int) bool { return c != 0 }
+	case "<":
+		accept = func(c int) bool { return c < 0 }
+	case "<=":
+		accept = func(c int) bool { return c <= 0 }
+	case ">":
+		accept = func(c int) bool { return c > 0 }
+	case ">=":
+		accept = func(c int) bool { return c >= 0 }
+	default:
+		return nil, errors.Errorf("unknown operator '%s'", op)
+	}
+	v, err := p.parseLiteral(dim)
+	if err != nil {
+		return nil, err
+	}
+	return func(item models.RecipeDelivery) bool {
+		return accept(v.compare(dim, item))
+	}, nil
+}
+
+func (p *parser) parseIn(dim processors.Dimension) (processors.RecipeDeliveryPredicate, error) {
+	if err := p.expectSymbol("("); err != nil {
+		return nil, err
+	}
+	var values []value
+	for {
+		v, err := p.parseLiteral(dim)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+		if !p.peek().isSymbol(",") {
+			break
+		}
+		p.next()
+	}
+	if err := p.expectSymbol(")"); err != nil {
+		return nil, err
+	}
+	return func(item models.RecipeDelivery) bool {
+		for _, v := range values {
+			if v.compare(dim, item) == 0 {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+func (p *parser) parseLike(dim processors.Dimension) (processors.RecipeDeliveryPredicate, error) {
+	t := p.next()
+	if t.kind != tkString {
+		return nil, p.unexpected(t)
+	}
+	var b strings.Builder
+	b.WriteString("^")
+	for _, c := range t.text {
+		switch c {
+		case '%':
+			b.WriteString(".*")
+		case '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, err
+	}
+	return func(item models.RecipeDelivery) bool {
+		return re.MatchString(dim.Value(item))
+	}, nil
+}
+
+func and(l, r processors.RecipeDeliveryPredicate) processors.RecipeDeliveryPredicate {
+	return func(item models.RecipeDelivery) bool {
+		return l(item) && r(item)
+	}
+}
+
+func or(l, r processors.RecipeDeliveryPredicate) processors.RecipeDeliveryPredicate {
+	return func(item models.RecipeDelivery) bool {
+		return l(item) || r(item)
+	}
+}
+
+func not(pred processors.RecipeDeliveryPredicate) processors.RecipeDeliveryPredicate {
+	return func(item models.RecipeDelivery) bool {
+		return !pred(item)
+	}
+}
+
+// value литерал, приведённый к типу измерения
+type value struct {
+	s string
+	n int
+}
+
+func parseValue(dim processors.Dimension, s string) (value, error) {
+	switch dim {
+	case processors.DimWeekday:
+		for wd := time.Sunday; wd <= time.Saturday; wd++ {
+			if strings.EqualFold(wd.String(), s) {
+				return value{n: int(wd)}, nil
+			}
+		}
+		return value{}, errors.Errorf("bad weekday '%s'", s)
+	case processors.DimFrom, processors.DimTo:
+		var h ts.Hour
+		if err := h.FromString([]byte(s)); err != nil {
+			return value{}, errors.Wrapf(err, "bad hour '%s'", s)
+		}
+		return value{n: int(h)}, nil
+	}
+	return value{s: s}, nil
+}
+
+func (v value) compare(dim processors.Dimension, item models.RecipeDelivery) int {
+	var n int
+	switch dim {
+	case processors.DimWeekday:
+		n = int(item.Delivery.WDay)
+	case processors.DimFrom:
+		n = int(item.Delivery.From)
+	case processors.DimTo:
+		n = int(item.Delivery.To)
+	default:
+		return strings.Compare(dim.Value(item), v.s)
+	}
+	switch {
+	case n < v.n:
+		return -1
+	case n > v.n:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,98 @@
+package query
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" //nolint:goimports
+	"sber-test/pkg/processors"
+	"sber-test/pkg/providers"
+	ts "sber-test/pkg/time-slot" //nolint:goimports
+)
+
+var testData = providers.RecipeDeliveries{
+	{Recipe: "Ink", Postcode: "1", Delivery: ts.ConstructDelivery(time.Monday, 10, 15)},
+	{Recipe: "Ink", Postcode: "1", Delivery: ts.ConstructDelivery(time.Thursday, 10, 15)},
+	{Recipe: "Ink", Postcode: "1", Delivery: ts.ConstructDelivery(time.Thursday, 18, 22)},
+	{Recipe: "B Potato", Postcode: "2", Delivery: ts.ConstructDelivery(time.Monday, 8, 15)},
+	{Recipe: "A Veggie", Postcode: "3", Delivery: ts.ConstructDelivery(time.Monday, 11, 20)},
+	{Recipe: "C Mushroom", Postcode: "3", Delivery: ts.ConstructDelivery(time.Monday, 11, 20)},
+	{Recipe: "C Mushroom", Postcode: "5", Delivery: ts.ConstructDelivery(time.Friday, 11, 22)},
+}
+
+func run(t *testing.T, src string) processors.RecipeProcessorReport {
+	q, err := Compile(src)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	subj, err := q.Subject()
+	assert.NoError(t, err)
+	report, err := processors.NewRecipeReportProcessor(subj).Process(context.Background(), testData)
+	assert.NoError(t, err)
+	assert.Len(t, report.Aggregations, 1)
+	return report
+}
+
+func keys(report processors.RecipeProcessorReport) []string {
+	var ret []string
+	for _, row := range report.Aggregations[0].Rows {
+		ret = append(ret, row.Key...)
+	}
+	return ret
+}
+
+func TestCompileGroupByOrderLimit(t *testing.T) {
+	report := run(t, "SELECT postcode, COUNT(*) FROM deliveries WHERE weekday='Monday' GROUP BY postcode ORDER BY 2 DESC LIMIT 2")
+	rows := report.Aggregations[0].Rows
+	assert.Equal(t, []string{"3", "1"}, keys(report))
+	assert.Equal(t, 2, rows[0].Count)
+	assert.Equal(t, 1, rows[1].Count)
+}
+
+func TestCompileCountDistinct(t *testing.T) {
+	report := run(t, "select count(distinct recipe), weekday from deliveries group by weekday order by count(distinct recipe) desc, weekday")
+	rows := report.Aggregations[0].Rows
+	assert.Equal(t, []string{"Monday", "Thursday", "Friday"}, keys(report))
+	assert.Equal(t, 4, *rows[0].Distinct)
+	assert.Equal(t, 4, rows[0].Count)
+}
+
+func TestCompileWhere(t *testing.T) {
+	cases := map[string]int{
+		"SELECT COUNT(*) FROM deliveries":                                              7,
+		"SELECT COUNT(*) FROM deliveries WHERE from >= '10AM' AND to <= 15":            2,
+		"SELECT COUNT(*) FROM deliveries WHERE recipe LIKE '%Mush%'":                   2,
+		"SELECT COUNT(*) FROM deliveries WHERE postcode NOT IN ('1', '3')":             2,
+		"SELECT COUNT(*) FROM deliveries WHERE NOT (weekday = 'monday' OR to > '8PM')": 1,
+		"SELECT COUNT(*) FROM deliveries WHERE recipe <> 'Ink';":                       4,
+		"SELECT COUNT(*) FROM deliveries WHERE recipe = 'Nothing'":                     0,
+	}
+	for src, expected := range cases {
+		report := run(t, src)
+		rows := report.Aggregations[0].Rows
+		assert.Len(t, rows, 1, src)
+		assert.Equal(t, expected, rows[0].Count, src)
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	bad := []string{
+		"",
+		"SELECT postcode FROM deliveries",
+		"SELECT COUNT(*) FROM orders",
+		"SELECT COUNT(*) FROM deliveries WHERE weekday = 'Funday'",
+		"SELECT COUNT(*) FROM deliveries WHERE from = '25PM'",
+		"SELECT postcode, COUNT(*) FROM deliveries GROUP BY postcode ORDER BY 3",
+		"SELECT COUNT(*) FROM deliveries ORDER BY recipe",
+		"SELECT COUNT(*) FROM deliveries WHERE recipe = 'Ink",
+		"SELECT COUNT(*) FROM deliveries LIMIT",
+		"SELECT COUNT(*) FROM deliveries extra",
+		"SELECT COUNT(*) FROM deliveries WHERE recipe ≠ 'Ink'",
+		"SELECT COUNT(*) FROM deliveries WHERE recipe = 'Ink' § 1",
+	}
+	for _, src := range bad {
+		_, err := Compile(src)
+		assert.Error(t, err, src)
+	}
+}
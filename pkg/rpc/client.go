@@ -0,0 +1,91 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/pkg/errors" //nolint:goimports
+	"google.golang.org/grpc"
+	"sber-test/pkg/models"
+	"sber-test/pkg/providers"
+	"sber-test/pkg/rpc/reportpb" //nolint:goimports
+)
+
+// DefaultBatchSize доставок в одном сообщении StreamReport
+const DefaultBatchSize = 1000
+
+// Client клиент ReportService
+type Client struct {
+	c reportpb.ReportServiceClient
+}
+
+// NewClient ...
+func NewClient(cc grpc.ClientConnInterface) *Client {
+	return &Client{c: reportpb.NewReportServiceClient(cc)}
+}
+
+// Report отчёт по источнику доставок сервера
+func (c *Client) Report(ctx context.Context, req *reportpb.ReportRequest, opts ...grpc.CallOption) (*reportpb.Report, error) {
+	const api = "Client.Report"
+
+	ret, err := c.c.GetReport(ctx, req, opts...)
+	return ret, errors.Wrap(err, api)
+}
+
+// StreamReport отправляет серверу доставки provider пачками по batchSize (<= 0 - DefaultBatchSize)
+// и возвращает отчёт по ним
+func (c *Client) StreamReport(ctx context.Context, req *reportpb.ReportRequest, provider providers.RecipeDeliveryProvider,
+	batchSize int, opts ...grpc.CallOption) (*reportpb.Report, error) {
+	const api = "Client.StreamReport"
+
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.c.StreamReport(ctx, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, api)
+	}
+	send := func(msg *reportpb.StreamReportRequest) error {
+		if err := stream.Send(msg); err != nil {
+			// сервер закрыл поток раньше времени; причину вернёт CloseAndRecv
+			if _, recvErr := stream.CloseAndRecv(); recvErr != nil {
+				return recvErr
+			}
+			return err
+		}
+		return nil
+	}
+	err = send(&reportpb.StreamReportRequest{
+		Payload: &reportpb.StreamReportRequest_Request{Request: req},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, api)
+	}
+	batch := &reportpb.RecipeDeliveries{Items: make([]*reportpb.RecipeDelivery, 0, batchSize)}
+	flush := func() error {
+		if len(batch.Items) == 0 {
+			return nil
+		}
+		err := send(&reportpb.StreamReportRequest{
+			Payload: &reportpb.StreamReportRequest_Deliveries{Deliveries: batch},
+		})
+		batch = &reportpb.RecipeDeliveries{Items: make([]*reportpb.RecipeDelivery, 0, batchSize)}
+		return err
+	}
+	err = provider.Provide(ctx, func(item models.RecipeDelivery) error {
+		batch.Items = append(batch.Items, DeliveryToProto(item))
+		if len(batch.Items) < batchSize {
+			return nil
+		}
+		return flush()
+	})
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, api)
+	}
+	ret, err := stream.CloseAndRecv()
+	return ret, errors.Wrap(err, api)
+}
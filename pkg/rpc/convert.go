@@ -0,0 +1,255 @@
+package rpc
+
+import (
+	"time"
+
+	"github.com/pkg/errors" //nolint:goimports
+	"sber-test/pkg/models"
+	"sber-test/pkg/processors"
+	"sber-test/pkg/rpc/reportpb"
+	ts "sber-test/pkg/time-slot" //nolint:goimports
+)
+
+// SubjectsFromRequest subjects отчёта, запрошенные в req
+func SubjectsFromRequest(req *reportpb.ReportRequest) ([]processors.RecipeReportSubj, error) {
+	const api = "SubjectsFromRequest"
+
+	approximate := req.GetApproximate()
+	if approximate < 0 || approximate >= 1 || (approximate > 0 && approximate < processors.MinApproxErrorRate) {
+		return nil, errors.Errorf("%s: 'approximate' has wrong value; expected 0 or [%g, 1)", api, processors.MinApproxErrorRate)
+	}
+	if approximate > 0 && !req.GetUniqueRecipeCount() && !req.GetBusiestPostcode() {
+		return nil, errors.Errorf("%s: 'approximate' requires 'unique_recipe_count' or 'busiest_postcode'", api)
+	}
+	var subjects []processors.RecipeReportSubj
+	if req.GetCountPerRecipe() {
+		subjects = append(subjects, processors.ReportCounterPerRecipe())
+	}
+	if req.GetUniqueRecipeCount() {
+		if approximate > 0 {
+			subjects = append(subjects, processors.ReportUniqueRecipesApprox(approximate))
+		} else {
+			subjects = append(subjects, processors.ReportUniqueRecipes())
+		}
+	}
+	if req.GetBusiestPostcode() {
+		if approximate > 0 {
+			subjects = append(subjects, processors.ReportBusiestPostcodeApprox(approximate, 0))
+		} else {
+			subjects = append(subjects, processors.ReportBusiestPostcode())
+		}
+	}
+	if names := req.GetMatchByName(); len(names) > 0 {
+		subjects = append(subjects, processors.ReportIfMatchedRecipes(names[0], names[1:]...))
+	}
+	if pt := req.GetDeliveriesByPostcodeAndTime(); pt != nil {
+		if pt.GetFrom() > 23 || pt.GetTo() > 23 || pt.GetFrom() > pt.GetTo() {
+			return nil, errors.Errorf("%s: 'deliveries_by_postcode_and_time' has wrong hours", api)
+		}
+		subjects = append(subjects,
+			processors.ReportDeliveryCountForPostcodeAndTime(pt.GetPostcode(), ts.Hour(pt.GetFrom()), ts.Hour(pt.GetTo())))
+	}
+	for _, g := range req.GetGroupBy() {
+		subj, err := groupBySubject(g)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s: 'group_by'", api)
+		}
+		subjects = append(subjects, subj)
+	}
+	if req.GetCountPerCategory() {
+		subjects = append(subjects, processors.ReportCountPerCategory())
+	}
+	if req.GetCountPerTag() {
+		subjects = append(subjects, processors.ReportCountPerTag())
+	}
+	if tags := req.GetTagShare(); len(tags) > 0 {
+		subjects = append(subjects, processors.ReportTagShare(tags[0], tags[1:]...))
+	}
+	if len(subjects) == 0 {
+		return nil, errors.Errorf("%s: asked no any subject to report", api)
+	}
+	return subjects, nil
+}
+
+// needsCatalog разделы запроса, которые строятся по каталогу рецептов
+func needsCatalog(req *reportpb.ReportRequest) bool {
+	return req.GetCountPerCategory() || req.GetCountPerTag() || len(req.GetTagShare()) > 0
+}
+
+// needsRegions запрос группирует по измерениям зоны доставки
+func needsRegions(req *reportpb.ReportRequest) bool {
+	for _, g := range req.GetGroupBy() {
+		if spec, err := groupBySpec(g); err == nil && spec.NeedsRegions() {
+			return true
+		}
+	}
+	return false
+}
+
+func groupBySubject(g *reportpb.ReportRequest_GroupBy) (processors.RecipeReportSubj, error) {
+	spec, err := groupBySpec(g)
+	if err != nil {
+		return nil, err
+	}
+	return processors.ReportGroupBy(spec)
+}
+
+func groupBySpec(g *reportpb.ReportRequest_GroupBy) (processors.GroupBySpec, error) {
+	var spec processors.GroupBySpec
+	for _, s := range g.GetBy() {
+		d, err := processors.ParseDimension(s)
+		if err != nil {
+			return spec, err
+		}
+		spec.By = append(spec.By, d)
+	}
+	if len(g.GetCountDistinct()) > 0 {
+		d, err := processors.ParseDimension(g.GetCountDistinct())
+		if err != nil {
+			return spec, err
+		}
+		spec.CountDistinct = d
+	}
+	for _, s := range g.GetOrderBy() {
+		o, err := processors.ParseGroupOrder(s)
+		if err != nil {
+			return spec, err
+		}
+		spec.OrderBy = append(spec.OrderBy, o)
+	}
+	spec.Limit = int(g.GetLimit())
+	return spec, nil
+}
+
+// DeliveryToProto ...
+func DeliveryToProto(item models.RecipeDelivery) *reportpb.RecipeDelivery {
+	ret := &reportpb.RecipeDelivery{
+		Postcode: item.Postcode,
+		Recipe:   item.Recipe,
+		Delivery: &reportpb.DeliverySlot{
+			Weekday: reportpb.Weekday(item.Delivery.WDay),
+			From:    uint32(item.Delivery.From),
+			To:      uint32(item.Delivery.To),
+		},
+	}
+	return ret
+}
+
+// DeliveryFromProto ...
+func DeliveryFromProto(item *reportpb.RecipeDelivery) (models.RecipeDelivery, error) {
+	const api = "DeliveryFromProto"
+
+	slot := item.GetDelivery()
+	if slot.GetWeekday() < reportpb.Weekday_WEEKDAY_SUNDAY || slot.GetWeekday() > reportpb.Weekday_WEEKDAY_SATURDAY {
+		return models.RecipeDelivery{}, errors.Errorf("%s: bad weekday %d", api, slot.GetWeekday())
+	}
+	if slot.GetFrom() > 23 || slot.GetTo() > 23 {
+		return models.RecipeDelivery{}, errors.Errorf("%s: bad delivery hours %d-%d", api, slot.GetFrom(), slot.GetTo())
+	}
+	return models.RecipeDelivery{
+		Postcode: item.GetPostcode(),
+		Recipe:   item.GetRecipe(),
+		Delivery: ts.ConstructDelivery(time.Weekday(slot.GetWeekday()), uint(slot.GetFrom()), uint(slot.GetTo())),
+	}, nil
+}
+
+func int64Ptr(p *int) *int64 {
+	if p == nil {
+		return nil
+	}
+	v := int64(*p)
+	return &v
+}
+
+// ReportToProto ...
+func ReportToProto(report processors.RecipeProcessorReport) *reportpb.Report {
+	ret := &reportpb.Report{
+		UniqueRecipeCount:      int64Ptr(report.UniqueRecipeCount),
+		UniqueRecipeCountError: int64Ptr(report.UniqueRecipeCountError),
+	}
+	if report.CountPerRecipe != nil {
+		ret.CountPerRecipe = &reportpb.Report_RecipeCounts{}
+		for _, c := range report.CountPerRecipe {
+			ret.CountPerRecipe.Items = append(ret.CountPerRecipe.Items,
+				&reportpb.Report_RecipeCount{Recipe: c.Recipe, Count: int64(c.Count)})
+			ret.CountPerRecipe.Count += int64(c.Count)
+		}
+	}
+	if b := report.BusiestPostcode; b != nil {
+		ret.BusiestPostcode = &reportpb.Report_BusiestPostcode{
+			Postcode:           b.Postcode,
+			DeliveryCount:      int64(b.DeliveryCount),
+			DeliveryCountError: int64Ptr(b.DeliveryCountError),
+		}
+	}
+	if c := report.CountPerPostcodeAndTime; c != nil {
+		ret.CountPerPostcodeAndTime = &reportpb.Report_PostcodeAndTimeCount{
+			Postcode:      c.Postcode,
+			From:          uint32(c.From),
+			To:            uint32(c.To),
+			DeliveryCount: int64(c.DeliveryCount),
+		}
+	}
+	if report.RecipesMatchedByName != nil {
+		ret.MatchByName = &reportpb.Report_Strings{Items: report.RecipesMatchedByName}
+	}
+	for _, agg := range report.Aggregations {
+		a := &reportpb.Report_Aggregation{GroupBy: agg.GroupBy, CountDistinct: agg.CountDistinct, Truncated: agg.Truncated}
+		for _, row := range agg.Rows {
+			a.Rows = append(a.Rows, &reportpb.Report_Aggregation_Row{
+				Key: row.Key, Count: int64(row.Count), Distinct: int64Ptr(row.Distinct),
+			})
+		}
+		ret.Aggregations = append(ret.Aggregations, a)
+	}
+	if report.MergedRecipes != nil {
+		ret.MergedRecipes = &reportpb.Report_MergedRecipes{}
+		for _, m := range report.MergedRecipes {
+			ret.MergedRecipes.Items = append(ret.MergedRecipes.Items,
+				&reportpb.Report_MergedRecipe{Recipe: m.Recipe, Variants: m.Variants})
+		}
+	}
+	if inv := report.InvalidPostcodes; inv != nil {
+		ret.InvalidPostcodes = &reportpb.Report_InvalidPostcodes{Count: int64(inv.Count)}
+		for _, p := range inv.Postcodes {
+			ret.InvalidPostcodes.Items = append(ret.InvalidPostcodes.Items,
+				&reportpb.Report_InvalidPostcode{Postcode: p.Postcode, Reason: p.Reason, Count: int64(p.Count)})
+		}
+	}
+	if un := report.UnmappedPostcodes; un != nil {
+		ret.UnmappedPostcodes = &reportpb.Report_PostcodeCounts{Count: int64(un.Count)}
+		for _, p := range un.Postcodes {
+			ret.UnmappedPostcodes.Items = append(ret.UnmappedPostcodes.Items,
+				&reportpb.Report_PostcodeCount{Postcode: p.Postcode, Count: int64(p.Count)})
+		}
+	}
+	if report.CountPerCategory != nil {
+		ret.CountPerCategory = &reportpb.Report_CategoryCounts{}
+		for _, c := range report.CountPerCategory {
+			ret.CountPerCategory.Items = append(ret.CountPerCategory.Items,
+				&reportpb.Report_CategoryCount{Category: c.Category, Count: int64(c.Count), Recipes: int64(c.Recipes)})
+		}
+	}
+	if report.CountPerTag != nil {
+		ret.CountPerTag = &reportpb.Report_TagCounts{}
+		for _, c := range report.CountPerTag {
+			ret.CountPerTag.Items = append(ret.CountPerTag.Items, &reportpb.Report_TagCount{Tag: c.Tag, Count: int64(c.Count)})
+		}
+	}
+	if report.TagShares != nil {
+		ret.TagShare = &reportpb.Report_TagShares{}
+		for _, s := range report.TagShares {
+			ret.TagShare.Items = append(ret.TagShare.Items, &reportpb.Report_TagShare{
+				Tag: s.Tag, DeliveryCount: int64(s.DeliveryCount), TotalCount: int64(s.TotalCount), Share: s.Share,
+			})
+		}
+	}
+	if un := report.UnknownRecipes; un != nil {
+		ret.UnknownRecipes = &reportpb.Report_RecipeCounts{Count: int64(un.Count)}
+		for _, r := range un.Recipes {
+			ret.UnknownRecipes.Items = append(ret.UnknownRecipes.Items,
+				&reportpb.Report_RecipeCount{Recipe: r.Recipe, Count: int64(r.Count)})
+		}
+	}
+	return ret
+}
@@ -0,0 +1,4 @@
+// Package reportpb protobuf-описание ReportService; *.pb.go сгенерированы из report.proto
+package reportpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative report.proto
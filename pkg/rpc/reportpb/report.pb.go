@@ -0,0 +1,2570 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: report.proto
+
+package reportpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Weekday совпадает с time.Weekday
+type Weekday int32
+
+const (
+	Weekday_WEEKDAY_SUNDAY    Weekday = 0
+	Weekday_WEEKDAY_MONDAY    Weekday = 1
+	Weekday_WEEKDAY_TUESDAY   Weekday = 2
+	Weekday_WEEKDAY_WEDNESDAY Weekday = 3
+	Weekday_WEEKDAY_THURSDAY  Weekday = 4
+	Weekday_WEEKDAY_FRIDAY    Weekday = 5
+	Weekday_WEEKDAY_SATURDAY  Weekday = 6
+)
+
+// Enum value maps for Weekday.
+var (
+	Weekday_name = map[int32]string{
+		0: "WEEKDAY_SUNDAY",
+		1: "WEEKDAY_MONDAY",
+		2: "WEEKDAY_TUESDAY",
+		3: "WEEKDAY_WEDNESDAY",
+		4: "WEEKDAY_THURSDAY",
+		5: "WEEKDAY_FRIDAY",
+		6: "WEEKDAY_SATURDAY",
+	}
+	Weekday_value = map[string]int32{
+		"WEEKDAY_SUNDAY":    0,
+		"WEEKDAY_MONDAY":    1,
+		"WEEKDAY_TUESDAY":   2,
+		"WEEKDAY_WEDNESDAY": 3,
+		"WEEKDAY_THURSDAY":  4,
+		"WEEKDAY_FRIDAY":    5,
+		"WEEKDAY_SATURDAY":  6,
+	}
+)
+
+func (x Weekday) Enum() *Weekday {
+	p := new(Weekday)
+	*p = x
+	return p
+}
+
+func (x Weekday) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Weekday) Descriptor() protoreflect.EnumDescriptor {
+	return file_report_proto_enumTypes[0].Descriptor()
+}
+
+func (Weekday) Type() protoreflect.EnumType {
+	return &file_report_proto_enumTypes[0]
+}
+
+func (x Weekday) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Weekday.Descriptor instead.
+func (Weekday) EnumDescriptor() ([]byte, []int) {
+	return file_report_proto_rawDescGZIP(), []int{0}
+}
+
+// DeliverySlot окно доставки; часы 0-23
+type DeliverySlot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Weekday Weekday `protobuf:"varint,1,opt,name=weekday,proto3,enum=sbertest.report.v1.Weekday" json:"weekday,omitempty"`
+	From    uint32  `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To      uint32  `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+}
+
+func (x *DeliverySlot) Reset() {
+	*x = DeliverySlot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_report_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeliverySlot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliverySlot) ProtoMessage() {}
+
+func (x *DeliverySlot) ProtoReflect() protoreflect.Message {
+	mi := &file_report_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliverySlot.ProtoReflect.Descriptor instead.
+func (*DeliverySlot) Descriptor() ([]byte, []int) {
+	return file_report_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DeliverySlot) GetWeekday() Weekday {
+	if x != nil {
+		return x.Weekday
+	}
+	return Weekday_WEEKDAY_SUNDAY
+}
+
+func (x *DeliverySlot) GetFrom() uint32 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *DeliverySlot) GetTo() uint32 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+type RecipeDelivery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Postcode string        `protobuf:"bytes,1,opt,name=postcode,proto3" json:"postcode,omitempty"`
+	Recipe   string        `protobuf:"bytes,2,opt,name=recipe,proto3" json:"recipe,omitempty"`
+	Delivery *DeliverySlot `protobuf:"bytes,3,opt,name=delivery,proto3" json:"delivery,omitempty"`
+}
+
+func (x *RecipeDelivery) Reset() {
+	*x = RecipeDelivery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_report_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecipeDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecipeDelivery) ProtoMessage() {}
+
+func (x *RecipeDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_report_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecipeDelivery.ProtoReflect.Descriptor instead.
+func (*RecipeDelivery) Descriptor() ([]byte, []int) {
+	return file_report_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RecipeDelivery) GetPostcode() string {
+	if x != nil {
+		return x.Postcode
+	}
+	return ""
+}
+
+func (x *RecipeDelivery) GetRecipe() string {
+	if x != nil {
+		return x.Recipe
+	}
+	return ""
+}
+
+func (x *RecipeDelivery) GetDelivery() *DeliverySlot {
+	if x != nil {
+		return x.Delivery
+	}
+	return nil
+}
+
+type RecipeDeliveries struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items []*RecipeDelivery `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *RecipeDeliveries) Reset() {
+	*x = RecipeDeliveries{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_report_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecipeDeliveries) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecipeDeliveries) ProtoMessage() {}
+
+func (x *RecipeDeliveries) ProtoReflect() protoreflect.Message {
+	mi := &file_report_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecipeDeliveries.ProtoReflect.Descriptor instead.
+func (*RecipeDeliveries) Descriptor() ([]byte, []int) {
+	return file_report_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RecipeDeliveries) GetItems() []*RecipeDelivery {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// ReportRequest какие разделы отчёта строить; соответствует параметрам командной строки
+type ReportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CountPerRecipe    bool `protobuf:"varint,1,opt,name=count_per_recipe,json=countPerRecipe,proto3" json:"count_per_recipe,omitempty"`
+	UniqueRecipeCount bool `protobuf:"varint,2,opt,name=unique_recipe_count,json=uniqueRecipeCount,proto3" json:"unique_recipe_count,omitempty"`
+	BusiestPostcode   bool `protobuf:"varint,3,opt,name=busiest_postcode,json=busiestPostcode,proto3" json:"busiest_postcode,omitempty"`
+	// match_by_name слова для поиска в "recipe name"
+	MatchByName                 []string                       `protobuf:"bytes,4,rep,name=match_by_name,json=matchByName,proto3" json:"match_by_name,omitempty"`
+	DeliveriesByPostcodeAndTime *ReportRequest_PostcodeAndTime `protobuf:"bytes,5,opt,name=deliveries_by_postcode_and_time,json=deliveriesByPostcodeAndTime,proto3" json:"deliveries_by_postcode_and_time,omitempty"`
+	GroupBy                     []*ReportRequest_GroupBy       `protobuf:"bytes,6,rep,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	// approximate относительная погрешность приближённых unique_recipe_count и busiest_postcode; 0 - точный подсчёт,
+	// иначе не меньше 0.0001
+	Approximate float64 `protobuf:"fixed64,7,opt,name=approximate,proto3" json:"approximate,omitempty"`
+	// count_per_category, count_per_tag и tag_share требуют каталога рецептов на сервере
+	CountPerCategory bool `protobuf:"varint,8,opt,name=count_per_category,json=countPerCategory,proto3" json:"count_per_category,omitempty"`
+	CountPerTag      bool `protobuf:"varint,9,opt,name=count_per_tag,json=countPerTag,proto3" json:"count_per_tag,omitempty"`
+	// tag_share теги, долю доставок рецептов с которыми нужно посчитать
+	TagShare []string `protobuf:"bytes,10,rep,name=tag_share,json=tagShare,proto3" json:"tag_share,omitempty"`
+}
+
+func (x *ReportRequest) Reset() {
+	*x = ReportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_report_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportRequest) ProtoMessage() {}
+
+func (x *ReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_report_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportRequest.ProtoReflect.Descriptor instead.
+func (*ReportRequest) Descriptor() ([]byte, []int) {
+	return file_report_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ReportRequest) GetCountPerRecipe() bool {
+	if x != nil {
+		return x.CountPerRecipe
+	}
+	return false
+}
+
+func (x *ReportRequest) GetUniqueRecipeCount() bool {
+	if x != nil {
+		return x.UniqueRecipeCount
+	}
+	return false
+}
+
+func (x *ReportRequest) GetBusiestPostcode() bool {
+	if x != nil {
+		return x.BusiestPostcode
+	}
+	return false
+}
+
+func (x *ReportRequest) GetMatchByName() []string {
+	if x != nil {
+		return x.MatchByName
+	}
+	return nil
+}
+
+func (x *ReportRequest) GetDeliveriesByPostcodeAndTime() *ReportRequest_PostcodeAndTime {
+	if x != nil {
+		return x.DeliveriesByPostcodeAndTime
+	}
+	return nil
+}
+
+func (x *ReportRequest) GetGroupBy() []*ReportRequest_GroupBy {
+	if x != nil {
+		return x.GroupBy
+	}
+	return nil
+}
+
+func (x *ReportRequest) GetApproximate() float64 {
+	if x != nil {
+		return x.Approximate
+	}
+	return 0
+}
+
+func (x *ReportRequest) GetCountPerCategory() bool {
+	if x != nil {
+		return x.CountPerCategory
+	}
+	return false
+}
+
+func (x *ReportRequest) GetCountPerTag() bool {
+	if x != nil {
+		return x.CountPerTag
+	}
+	return false
+}
+
+func (x *ReportRequest) GetTagShare() []string {
+	if x != nil {
+		return x.TagShare
+	}
+	return nil
+}
+
+type StreamReportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Payload:
+	//	*StreamReportRequest_Request
+	//	*StreamReportRequest_Deliveries
+	Payload isStreamReportRequest_Payload `protobuf_oneof:"payload"`
+}
+
+func (x *StreamReportRequest) Reset() {
+	*x = StreamReportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_report_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamReportRequest) ProtoMessage() {}
+
+func (x *StreamReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_report_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamReportRequest.ProtoReflect.Descriptor instead.
+func (*StreamReportRequest) Descriptor() ([]byte, []int) {
+	return file_report_proto_rawDescGZIP(), []int{4}
+}
+
+func (m *StreamReportRequest) GetPayload() isStreamReportRequest_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (x *StreamReportRequest) GetRequest() *ReportRequest {
+	if x, ok := x.GetPayload().(*StreamReportRequest_Request); ok {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *StreamReportRequest) GetDeliveries() *RecipeDeliveries {
+	if x, ok := x.GetPayload().(*StreamReportRequest_Deliveries); ok {
+		return x.Deliveries
+	}
+	return nil
+}
+
+type isStreamReportRequest_Payload interface {
+	isStreamReportRequest_Payload()
+}
+
+type StreamReportRequest_Request struct {
+	Request *ReportRequest `protobuf:"bytes,1,opt,name=request,proto3,oneof"`
+}
+
+type StreamReportRequest_Deliveries struct {
+	Deliveries *RecipeDeliveries `protobuf:"bytes,2,opt,name=deliveries,proto3,oneof"`
+}
+
+func (*StreamReportRequest_Request) isStreamReportRequest_Payload() {}
+
+func (*StreamReportRequest_Deliveries) isStreamReportRequest_Payload() {}
+
+// Report RecipeProcessorReport; незапрошенные разделы не заполнены
+type Report struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UniqueRecipeCount       *int64                       `protobuf:"varint,1,opt,name=unique_recipe_count,json=uniqueRecipeCount,proto3,oneof" json:"unique_recipe_count,omitempty"`
+	UniqueRecipeCountError  *int64                       `protobuf:"varint,2,opt,name=unique_recipe_count_error,json=uniqueRecipeCountError,proto3,oneof" json:"unique_recipe_count_error,omitempty"`
+	CountPerRecipe          *Report_RecipeCounts         `protobuf:"bytes,3,opt,name=count_per_recipe,json=countPerRecipe,proto3" json:"count_per_recipe,omitempty"`
+	BusiestPostcode         *Report_BusiestPostcode      `protobuf:"bytes,4,opt,name=busiest_postcode,json=busiestPostcode,proto3" json:"busiest_postcode,omitempty"`
+	CountPerPostcodeAndTime *Report_PostcodeAndTimeCount `protobuf:"bytes,5,opt,name=count_per_postcode_and_time,json=countPerPostcodeAndTime,proto3" json:"count_per_postcode_and_time,omitempty"`
+	MatchByName             *Report_Strings              `protobuf:"bytes,6,opt,name=match_by_name,json=matchByName,proto3" json:"match_by_name,omitempty"`
+	Aggregations            []*Report_Aggregation        `protobuf:"bytes,7,rep,name=aggregations,proto3" json:"aggregations,omitempty"`
+	MergedRecipes           *Report_MergedRecipes        `protobuf:"bytes,8,opt,name=merged_recipes,json=mergedRecipes,proto3" json:"merged_recipes,omitempty"`
+	InvalidPostcodes        *Report_InvalidPostcodes     `protobuf:"bytes,9,opt,name=invalid_postcodes,json=invalidPostcodes,proto3" json:"invalid_postcodes,omitempty"`
+	UnmappedPostcodes       *Report_PostcodeCounts       `protobuf:"bytes,10,opt,name=unmapped_postcodes,json=unmappedPostcodes,proto3" json:"unmapped_postcodes,omitempty"`
+	CountPerCategory        *Report_CategoryCounts       `protobuf:"bytes,11,opt,name=count_per_category,json=countPerCategory,proto3" json:"count_per_category,omitempty"`
+	CountPerTag             *Report_TagCounts            `protobuf:"bytes,12,opt,name=count_per_tag,json=countPerTag,proto3" json:"count_per_tag,omitempty"`
+	TagShare                *Report_TagShares            `protobuf:"bytes,13,opt,name=tag_share,json=tagShare,proto3" json:"tag_share,omitempty"`
+	UnknownRecipes          *Report_RecipeCounts         `protobuf:"bytes,14,opt,name=unknown_recipes,json=unknownRecipes,proto3" json:"unknown_recipes,omitempty"`
+}
+
+func (x *Report) Reset() {
+	*x = Report{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_report_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Report) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report) ProtoMessage() {}
+
+func (x *Report) ProtoReflect() protoreflect.Message {
+	mi := &file_report_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report.ProtoReflect.Descriptor instead.
+func (*Report) Descriptor() ([]byte, []int) {
+	return file_report_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Report) GetUniqueRecipeCount() int64 {
+	if x != nil && x.UniqueRecipeCount != nil {
+		return *x.UniqueRecipeCount
+	}
+	return 0
+}
+
+func (x *Report) GetUniqueRecipeCountError() int64 {
+	if x != nil && x.UniqueRecipeCountError != nil {
+		return *x.UniqueRecipeCountError
+	}
+	return 0
+}
+
+func (x *Report) GetCountPerRecipe() *Report_RecipeCounts {
+	if x != nil {
+		return x.CountPerRecipe
+	}
+	return nil
+}
+
+func (x *Report) GetBusiestPostcode() *Report_BusiestPostcode {
+	if x != nil {
+		return x.BusiestPostcode
+	}
+	return nil
+}
+
+func (x *Report) GetCountPerPostcodeAndTime() *Report_PostcodeAndTimeCount {
+	if x != nil {
+		return x.CountPerPostcodeAndTime
+	}
+	return nil
+}
+
+func (x *Report) GetMatchByName() *Report_Strings {
+	if x != nil {
+		return x.MatchByName
+	}
+	return nil
+}
+
+func (x *Report) GetAggregations() []*Report_Aggregation {
+	if x != nil {
+		return x.Aggregations
+	}
+	return nil
+}
+
+func (x *Report) GetMergedRecipes() *Report_MergedRecipes {
+	if x != nil {
+		return x.MergedRecipes
+	}
+	return nil
+}
+
+func (x *Report) GetInvalidPostcodes() *Report_InvalidPostcodes {
+	if x != nil {
+		return x.InvalidPostcodes
+	}
+	return nil
+}
+
+func (x *Report) GetUnmappedPostcodes() *Report_PostcodeCounts {
+	if x != nil {
+		return x.UnmappedPostcodes
+	}
+	return nil
+}
+
+func (x *Report) GetCountPerCategory() *Report_CategoryCounts {
+	if x != nil {
+		return x.CountPerCategory
+	}
+	return nil
+}
+
+func (x *Report) GetCountPerTag() *Report_TagCounts {
+	if x != nil {
+		return x.CountPerTag
+	}
+	return nil
+}
+
+func (x *Report) GetTagShare() *Report_TagShares {
+	if x != nil {
+		return x.TagShare
+	}
+	return nil
+}
+
+func (x *Report) GetUnknownRecipes() *Report_RecipeCounts {
+	if x != nil {
+		return x.UnknownRecipes
+	}
+	return nil
+}
+
+type ReportRequest_PostcodeAndTime struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Postcode string `protobuf:"bytes,1,opt,name=postcode,proto3" json:"postcode,omitempty"`
+	From     uint32 `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To       uint32 `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+}
+
+func (x *ReportRequest_PostcodeAndTime) Reset() {
+	*x = ReportRequest_PostcodeAndTime{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_report_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReportRequest_PostcodeAndTime) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportRequest_PostcodeAndTime) ProtoMessage() {}
+
+func (x *ReportRequest_PostcodeAndTime) ProtoReflect() protoreflect.Message {
+	mi := &file_report_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportRequest_PostcodeAndTime.ProtoReflect.Descriptor instead.
+func (*ReportRequest_PostcodeAndTime) Descriptor() ([]byte, []int) {
+	return file_report_proto_rawDescGZIP(), []int{3, 0}
+}
+
+func (x *ReportRequest_PostcodeAndTime) GetPostcode() string {
+	if x != nil {
+		return x.Postcode
+	}
+	return ""
+}
+
+func (x *ReportRequest_PostcodeAndTime) GetFrom() uint32 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *ReportRequest_PostcodeAndTime) GetTo() uint32 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+type ReportRequest_GroupBy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	By            []string `protobuf:"bytes,1,rep,name=by,proto3" json:"by,omitempty"`
+	CountDistinct string   `protobuf:"bytes,2,opt,name=count_distinct,json=countDistinct,proto3" json:"count_distinct,omitempty"`
+	// order_by столбцы в виде "col[:desc]"
+	OrderBy []string `protobuf:"bytes,3,rep,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	Limit   int32    `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ReportRequest_GroupBy) Reset() {
+	*x = ReportRequest_GroupBy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_report_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReportRequest_GroupBy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportRequest_GroupBy) ProtoMessage() {}
+
+func (x *ReportRequest_GroupBy) ProtoReflect() protoreflect.Message {
+	mi := &file_report_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportRequest_GroupBy.ProtoReflect.Descriptor instead.
+func (*ReportRequest_GroupBy) Descriptor() ([]byte, []int) {
+	return file_report_proto_rawDescGZIP(), []int{3, 1}
+}
+
+func (x *ReportRequest_GroupBy) GetBy() []string {
+	if x != nil {
+		return x.By
+	}
+	return nil
+}
+
+func (x *ReportRequest_GroupBy) GetCountDistinct() string {
+	if x != nil {
+		return x.CountDistinct
+	}
+	return ""
+}
+
+func (x *ReportRequest_GroupBy) GetOrderBy() []string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return nil
+}
+
+func (x *ReportRequest_GroupBy) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type Report_Strings struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items []string `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *Report_Strings) Reset() {
+	*x = Report_Strings{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_report_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Report_Strings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report_Strings) ProtoMessage() {}
+
+func (x *Report_Strings) ProtoReflect() protoreflect.Message {
+	mi := &file_report_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report_Strings.ProtoReflect.Descriptor instead.
+func (*Report_Strings) Descriptor() ([]byte, []int) {
+	return file_report_proto_rawDescGZIP(), []int{5, 0}
+}
+
+func (x *Report_Strings) GetItems() []string {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type Report_RecipeCount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Recipe string `protobuf:"bytes,1,opt,name=recipe,proto3" json:"recipe,omitempty"`
+	Count  int64  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *Report_RecipeCount) Reset() {
+	*x = Report_RecipeCount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_report_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Report_RecipeCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report_RecipeCount) ProtoMessage() {}
+
+func (x *Report_RecipeCount) ProtoReflect() protoreflect.Message {
+	mi := &file_report_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report_RecipeCount.ProtoReflect.Descriptor instead.
+func (*Report_RecipeCount) Descriptor() ([]byte, []int) {
+	return file_report_proto_rawDescGZIP(), []int{5, 1}
+}
+
+func (x *Report_RecipeCount) GetRecipe() string {
+	if x != nil {
+		return x.Recipe
+	}
+	return ""
+}
+
+func (x *Report_RecipeCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type Report_RecipeCounts struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// count всего доставок; заполняется для unknown_recipes
+	Count int64                 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Items []*Report_RecipeCount `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *Report_RecipeCounts) Reset() {
+	*x = Report_RecipeCounts{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_report_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Report_RecipeCounts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report_RecipeCounts) ProtoMessage() {}
+
+func (x *Report_RecipeCounts) ProtoReflect() protoreflect.Message {
+	mi := &file_report_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report_RecipeCounts.ProtoReflect.Descriptor instead.
+func (*Report_RecipeCounts) Descriptor() ([]byte, []int) {
+	return file_report_proto_rawDescGZIP(), []int{5, 2}
+}
+
+func (x *Report_RecipeCounts) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *Report_RecipeCounts) GetItems() []*Report_RecipeCount {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type Report_BusiestPostcode struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Postcode           string `protobuf:"bytes,1,opt,name=postcode,proto3" json:"postcode,omitempty"`
+	DeliveryCount      int64  `protobuf:"varint,2,opt,name=delivery_count,json=deliveryCount,proto3" json:"delivery_count,omitempty"`
+	DeliveryCountError *int64 `protobuf:"varint,3,opt,name=delivery_count_error,json=deliveryCountError,proto3,oneof" json:"delivery_count_error,omitempty"`
+}
+
+func (x *Report_BusiestPostcode) Reset() {
+	*x = Report_BusiestPostcode{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_report_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Report_BusiestPostcode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report_BusiestPostcode) ProtoMessage() {}
+
+func (x *Report_BusiestPostcode) ProtoReflect() protoreflect.Message {
+	mi := &file_report_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report_BusiestPostcode.ProtoReflect.Descriptor instead.
+func (*Report_BusiestPostcode) Descriptor() ([]byte, []int) {
+	return file_report_proto_rawDescGZIP(), []int{5, 3}
+}
+
+func (x *Report_BusiestPostcode) GetPostcode() string {
+	if x != nil {
+		return x.Postcode
+	}
+	return ""
+}
+
+func (x *Report_BusiestPostcode) GetDeliveryCount() int64 {
+	if x != nil {
+		return x.DeliveryCount
+	}
+	return 0
+}
+
+func (x *Report_BusiestPostcode) GetDeliveryCountError() int64 {
+	if x != nil && x.DeliveryCountError != nil {
+		return *x.DeliveryCountError
+	}
+	return 0
+}
+
+type Report_PostcodeAndTimeCount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Postcode      string `protobuf:"bytes,1,opt,name=postcode,proto3" json:"postcode,omitempty"`
+	From          uint32 `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            uint32 `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	DeliveryCount int64  `protobuf:"varint,4,opt,name=delivery_count,json=deliveryCount,proto3" json:"delivery_count,omitempty"`
+}
+
+func (x *Report_PostcodeAndTimeCount) Reset() {
+	*x = Report_PostcodeAndTimeCount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_report_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Report_PostcodeAndTimeCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report_PostcodeAndTimeCount) ProtoMessage() {}
+
+func (x *Report_PostcodeAndTimeCount) ProtoReflect() protoreflect.Message {
+	mi := &file_report_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report_PostcodeAndTimeCount.ProtoReflect.Descriptor instead.
+func (*Report_PostcodeAndTimeCount) Descriptor() ([]byte, []int) {
+	return file_report_proto_rawDescGZIP(), []int{5, 4}
+}
+
+func (x *Report_PostcodeAndTimeCount) GetPostcode() string {
+	if x != nil {
+		return x.Postcode
+	}
+	return ""
+}
+
+func (x *Report_PostcodeAndTimeCount) GetFrom() uint32 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *Report_PostcodeAndTimeCount) GetTo() uint32 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *Report_PostcodeAndTimeCount) GetDeliveryCount() int64 {
+	if x != nil {
+		return x.DeliveryCount
+	}
+	return 0
+}
+
+type Report_Aggregation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GroupBy       []string                  `protobuf:"bytes,1,rep,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	CountDistinct string                    `protobuf:"bytes,2,opt,name=count_distinct,json=countDistinct,proto3" json:"count_distinct,omitempty"`
+	Rows          []*Report_Aggregation_Row `protobuf:"bytes,3,rep,name=rows,proto3" json:"rows,omitempty"`
+	// truncated строки сверх limit отброшены
+	Truncated bool `protobuf:"varint,4,opt,name=truncated,proto3" json:"truncated,omitempty"`
+}
+
+func (x *Report_Aggregation) Reset() {
+	*x = Report_Aggregation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_report_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Report_Aggregation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report_Aggregation) ProtoMessage() {}
+
+func (x *Report_Aggregation) ProtoReflect() protoreflect.Message {
+	mi := &file_report_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report_Aggregation.ProtoReflect.Descriptor instead.
+func (*Report_Aggregation) Descriptor() ([]byte, []int) {
+	return file_report_proto_rawDescGZIP(), []int{5, 5}
+}
+
+func (x *Report_Aggregation) GetGroupBy() []string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return nil
+}
+
+func (x *Report_Aggregation) GetCountDistinct() string {
+	if x != nil {
+		return x.CountDistinct
+	}
+	return ""
+}
+
+func (x *Report_Aggregation) GetRows() []*Report_Aggregation_Row {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *Report_Aggregation) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+type Report_MergedRecipe struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Recipe   string   `protobuf:"bytes,1,opt,name=recipe,proto3" json:"recipe,omitempty"`
+	Variants []string `protobuf:"bytes,2,rep,name=variants,proto3" json:"variants,omitempty"`
+}
+
+func (x *Report_MergedRecipe) Reset() {
+	*x = Report_MergedRecipe{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_report_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Report_MergedRecipe) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report_MergedRecipe) ProtoMessage() {}
+
+func (x *Report_MergedRecipe) ProtoReflect() protoreflect.Message {
+	mi := &file_report_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report_MergedRecipe.ProtoReflect.Descriptor instead.
+func (*Report_MergedRecipe) Descriptor() ([]byte, []int) {
+	return file_report_proto_rawDescGZIP(), []int{5, 6}
+}
+
+func (x *Report_MergedRecipe) GetRecipe() string {
+	if x != nil {
+		return x.Recipe
+	}
+	return ""
+}
+
+func (x *Report_MergedRecipe) GetVariants() []string {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+type Report_MergedRecipes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items []*Report_MergedRecipe `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *Report_MergedRecipes) Reset() {
+	*x = Report_MergedRecipes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_report_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Report_MergedRecipes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report_MergedRecipes) ProtoMessage() {}
+
+func (x *Report_MergedRecipes) ProtoReflect() protoreflect.Message {
+	mi := &file_report_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report_MergedRecipes.ProtoReflect.Descriptor instead.
+func (*Report_MergedRecipes) Descriptor() ([]byte, []int) {
+	return file_report_proto_rawDescGZIP(), []int{5, 7}
+}
+
+func (x *Report_MergedRecipes) GetItems() []*Report_MergedRecipe {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type Report_InvalidPostcode struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Postcode string `protobuf:"bytes,1,opt,name=postcode,proto3" json:"postcode,omitempty"`
+	Reason   string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Count    int64  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *Report_InvalidPostcode) Reset() {
+	*x = Report_InvalidPostcode{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_report_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Report_InvalidPostcode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report_InvalidPostcode) ProtoMessage() {}
+
+func (x *Report_InvalidPostcode) ProtoReflect() protoreflect.Message {
+	mi := &file_report_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report_InvalidPostcode.ProtoReflect.Descriptor instead.
+func (*Report_InvalidPostcode) Descriptor() ([]byte, []int) {
+	return file_report_proto_rawDescGZIP(), []int{5, 8}
+}
+
+func (x *Report_InvalidPostcode) GetPostcode() string {
+	if x != nil {
+		return x.Postcode
+	}
+	return ""
+}
+
+func (x *Report_InvalidPostcode) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Report_InvalidPostcode) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type Report_InvalidPostcodes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count int64                     `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Items []*Report_InvalidPostcode `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *Report_InvalidPostcodes) Reset() {
+	*x = Report_InvalidPostcodes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_report_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Report_InvalidPostcodes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report_InvalidPostcodes) ProtoMessage() {}
+
+func (x *Report_InvalidPostcodes) ProtoReflect() protoreflect.Message {
+	mi := &file_report_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report_InvalidPostcodes.ProtoReflect.Descriptor instead.
+func (*Report_InvalidPostcodes) Descriptor() ([]byte, []int) {
+	return file_report_proto_rawDescGZIP(), []int{5, 9}
+}
+
+func (x *Report_InvalidPostcodes) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *Report_InvalidPostcodes) GetItems() []*Report_InvalidPostcode {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type Report_PostcodeCount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Postcode string `protobuf:"bytes,1,opt,name=postcode,proto3" json:"postcode,omitempty"`
+	Count    int64  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *Report_PostcodeCount) Reset() {
+	*x = Report_PostcodeCount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_report_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Report_PostcodeCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report_PostcodeCount) ProtoMessage() {}
+
+func (x *Report_PostcodeCount) ProtoReflect() protoreflect.Message {
+	mi := &file_report_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report_PostcodeCount.ProtoReflect.Descriptor instead.
+func (*Report_PostcodeCount) Descriptor() ([]byte, []int) {
+	return file_report_proto_rawDescGZIP(), []int{5, 10}
+}
+
+func (x *Report_PostcodeCount) GetPostcode() string {
+	if x != nil {
+		return x.Postcode
+	}
+	return ""
+}
+
+func (x *Report_PostcodeCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type Report_PostcodeCounts struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count int64                   `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Items []*Report_PostcodeCount `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *Report_PostcodeCounts) Reset() {
+	*x = Report_PostcodeCounts{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_report_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Report_PostcodeCounts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report_PostcodeCounts) ProtoMessage() {}
+
+func (x *Report_PostcodeCounts) ProtoReflect() protoreflect.Message {
+	mi := &file_report_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report_PostcodeCounts.ProtoReflect.Descriptor instead.
+func (*Report_PostcodeCounts) Descriptor() ([]byte, []int) {
+	return file_report_proto_rawDescGZIP(), []int{5, 11}
+}
+
+func (x *Report_PostcodeCounts) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *Report_PostcodeCounts) GetItems() []*Report_PostcodeCount {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type Report_CategoryCount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Category string `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Count    int64  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Recipes  int64  `protobuf:"varint,3,opt,name=recipes,proto3" json:"recipes,omitempty"`
+}
+
+func (x *Report_CategoryCount) Reset() {
+	*x = Report_CategoryCount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_report_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Report_CategoryCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report_CategoryCount) ProtoMessage() {}
+
+func (x *Report_CategoryCount) ProtoReflect() protoreflect.Message {
+	mi := &file_report_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report_CategoryCount.ProtoReflect.Descriptor instead.
+func (*Report_CategoryCount) Descriptor() ([]byte, []int) {
+	return file_report_proto_rawDescGZIP(), []int{5, 12}
+}
+
+func (x *Report_CategoryCount) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Report_CategoryCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *Report_CategoryCount) GetRecipes() int64 {
+	if x != nil {
+		return x.Recipes
+	}
+	return 0
+}
+
+type Report_CategoryCounts struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items []*Report_CategoryCount `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *Report_CategoryCounts) Reset() {
+	*x = Report_CategoryCounts{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_report_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Report_CategoryCounts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report_CategoryCounts) ProtoMessage() {}
+
+func (x *Report_CategoryCounts) ProtoReflect() protoreflect.Message {
+	mi := &file_report_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report_CategoryCounts.ProtoReflect.Descriptor instead.
+func (*Report_CategoryCounts) Descriptor() ([]byte, []int) {
+	return file_report_proto_rawDescGZIP(), []int{5, 13}
+}
+
+func (x *Report_CategoryCounts) GetItems() []*Report_CategoryCount {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type Report_TagCount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tag   string `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Count int64  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *Report_TagCount) Reset() {
+	*x = Report_TagCount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_report_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Report_TagCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report_TagCount) ProtoMessage() {}
+
+func (x *Report_TagCount) ProtoReflect() protoreflect.Message {
+	mi := &file_report_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report_TagCount.ProtoReflect.Descriptor instead.
+func (*Report_TagCount) Descriptor() ([]byte, []int) {
+	return file_report_proto_rawDescGZIP(), []int{5, 14}
+}
+
+func (x *Report_TagCount) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *Report_TagCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type Report_TagCounts struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items []*Report_TagCount `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *Report_TagCounts) Reset() {
+	*x = Report_TagCounts{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_report_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Report_TagCounts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report_TagCounts) ProtoMessage() {}
+
+func (x *Report_TagCounts) ProtoReflect() protoreflect.Message {
+	mi := &file_report_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report_TagCounts.ProtoReflect.Descriptor instead.
+func (*Report_TagCounts) Descriptor() ([]byte, []int) {
+	return file_report_proto_rawDescGZIP(), []int{5, 15}
+}
+
+func (x *Report_TagCounts) GetItems() []*Report_TagCount {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type Report_TagShare struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tag           string  `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	DeliveryCount int64   `protobuf:"varint,2,opt,name=delivery_count,json=deliveryCount,proto3" json:"delivery_count,omitempty"`
+	TotalCount    int64   `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Share         float64 `protobuf:"fixed64,4,opt,name=share,proto3" json:"share,omitempty"`
+}
+
+func (x *Report_TagShare) Reset() {
+	*x = Report_TagShare{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_report_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Report_TagShare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report_TagShare) ProtoMessage() {}
+
+func (x *Report_TagShare) ProtoReflect() protoreflect.Message {
+	mi := &file_report_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report_TagShare.ProtoReflect.Descriptor instead.
+func (*Report_TagShare) Descriptor() ([]byte, []int) {
+	return file_report_proto_rawDescGZIP(), []int{5, 16}
+}
+
+func (x *Report_TagShare) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *Report_TagShare) GetDeliveryCount() int64 {
+	if x != nil {
+		return x.DeliveryCount
+	}
+	return 0
+}
+
+func (x *Report_TagShare) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *Report_TagShare) GetShare() float64 {
+	if x != nil {
+		return x.Share
+	}
+	return 0
+}
+
+type Report_TagShares struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items []*Report_TagShare `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *Report_TagShares) Reset() {
+	*x = Report_TagShares{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_report_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Report_TagShares) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report_TagShares) ProtoMessage() {}
+
+func (x *Report_TagShares) ProtoReflect() protoreflect.Message {
+	mi := &file_report_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report_TagShares.ProtoReflect.Descriptor instead.
+func (*Report_TagShares) Descriptor() ([]byte, []int) {
+	return file_report_proto_rawDescGZIP(), []int{5, 17}
+}
+
+func (x *Report_TagShares) GetItems() []*Report_TagShare {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type Report_Aggregation_Row struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key      []string `protobuf:"bytes,1,rep,name=key,proto3" json:"key,omitempty"`
+	Count    int64    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Distinct *int64   `protobuf:"varint,3,opt,name=distinct,proto3,oneof" json:"distinct,omitempty"`
+}
+
+func (x *Report_Aggregation_Row) Reset() {
+	*x = Report_Aggregation_Row{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_report_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Report_Aggregation_Row) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report_Aggregation_Row) ProtoMessage() {}
+
+func (x *Report_Aggregation_Row) ProtoReflect() protoreflect.Message {
+	mi := &file_report_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report_Aggregation_Row.ProtoReflect.Descriptor instead.
+func (*Report_Aggregation_Row) Descriptor() ([]byte, []int) {
+	return file_report_proto_rawDescGZIP(), []int{5, 5, 0}
+}
+
+func (x *Report_Aggregation_Row) GetKey() []string {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *Report_Aggregation_Row) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *Report_Aggregation_Row) GetDistinct() int64 {
+	if x != nil && x.Distinct != nil {
+		return *x.Distinct
+	}
+	return 0
+}
+
+var File_report_proto protoreflect.FileDescriptor
+
+var file_report_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x12,
+	0x73, 0x62, 0x65, 0x72, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e,
+	0x76, 0x31, 0x22, 0x69, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x53, 0x6c,
+	0x6f, 0x74, 0x12, 0x35, 0x0a, 0x07, 0x77, 0x65, 0x65, 0x6b, 0x64, 0x61, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x73, 0x62, 0x65, 0x72, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x65, 0x6b, 0x64, 0x61, 0x79,
+	0x52, 0x07, 0x77, 0x65, 0x65, 0x6b, 0x64, 0x61, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f,
+	0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a,
+	0x02, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x74, 0x6f, 0x22, 0x90, 0x01,
+	0x0a, 0x0e, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79,
+	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x74, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x74, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65,
+	0x63, 0x69, 0x70, 0x65, 0x12, 0x3c, 0x0a, 0x08, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x73, 0x62, 0x65, 0x72, 0x74, 0x65, 0x73,
+	0x74, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x69,
+	0x76, 0x65, 0x72, 0x79, 0x53, 0x6c, 0x6f, 0x74, 0x52, 0x08, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65,
+	0x72, 0x79, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e,
+	0x22, 0x4c, 0x0a, 0x10, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65,
+	0x72, 0x69, 0x65, 0x73, 0x12, 0x38, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x73, 0x62, 0x65, 0x72, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x44,
+	0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0xce,
+	0x05, 0x0a, 0x0d, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x28, 0x0a, 0x10, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x72, 0x65,
+	0x63, 0x69, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x50, 0x65, 0x72, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x75, 0x6e,
+	0x69, 0x71, 0x75, 0x65, 0x5f, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x52,
+	0x65, 0x63, 0x69, 0x70, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x62, 0x75,
+	0x73, 0x69, 0x65, 0x73, 0x74, 0x5f, 0x70, 0x6f, 0x73, 0x74, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x62, 0x75, 0x73, 0x69, 0x65, 0x73, 0x74, 0x50, 0x6f, 0x73,
+	0x74, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x62,
+	0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x61,
+	0x74, 0x63, 0x68, 0x42, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x77, 0x0a, 0x1f, 0x64, 0x65, 0x6c,
+	0x69, 0x76, 0x65, 0x72, 0x69, 0x65, 0x73, 0x5f, 0x62, 0x79, 0x5f, 0x70, 0x6f, 0x73, 0x74, 0x63,
+	0x6f, 0x64, 0x65, 0x5f, 0x61, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x31, 0x2e, 0x73, 0x62, 0x65, 0x72, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x63, 0x6f, 0x64, 0x65, 0x41, 0x6e,
+	0x64, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x1b, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x69, 0x65,
+	0x73, 0x42, 0x79, 0x50, 0x6f, 0x73, 0x74, 0x63, 0x6f, 0x64, 0x65, 0x41, 0x6e, 0x64, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x44, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x62, 0x79, 0x18, 0x06,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x73, 0x62, 0x65, 0x72, 0x74, 0x65, 0x73, 0x74, 0x2e,
+	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x42, 0x79, 0x52,
+	0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x42, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x70, 0x70, 0x72,
+	0x6f, 0x78, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x61,
+	0x70, 0x70, 0x72, 0x6f, 0x78, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x50, 0x65, 0x72,
+	0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x22, 0x0a, 0x0d, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x74, 0x61, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0b, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x50, 0x65, 0x72, 0x54, 0x61, 0x67, 0x12, 0x1b, 0x0a, 0x09,
+	0x74, 0x61, 0x67, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x08, 0x74, 0x61, 0x67, 0x53, 0x68, 0x61, 0x72, 0x65, 0x1a, 0x51, 0x0a, 0x0f, 0x50, 0x6f, 0x73,
+	0x74, 0x63, 0x6f, 0x64, 0x65, 0x41, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x70, 0x6f, 0x73, 0x74, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x70, 0x6f, 0x73, 0x74, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02,
+	0x74, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x74, 0x6f, 0x1a, 0x71, 0x0a, 0x07,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x42, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x62, 0x79, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x02, 0x62, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x5f, 0x64, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x12, 0x19,
+	0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x62, 0x79, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22,
+	0xa7, 0x01, 0x0a, 0x13, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3d, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x73, 0x62, 0x65, 0x72, 0x74,
+	0x65, 0x73, 0x74, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x07, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x46, 0x0a, 0x0a, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65,
+	0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x73, 0x62, 0x65,
+	0x72, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x69, 0x65, 0x73,
+	0x48, 0x00, 0x52, 0x0a, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x69, 0x65, 0x73, 0x42, 0x09,
+	0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x89, 0x17, 0x0a, 0x06, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x12, 0x33, 0x0a, 0x13, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x5f, 0x72,
+	0x65, 0x63, 0x69, 0x70, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x48, 0x00, 0x52, 0x11, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x52, 0x65, 0x63, 0x69, 0x70,
+	0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x12, 0x3e, 0x0a, 0x19, 0x75, 0x6e, 0x69,
+	0x71, 0x75, 0x65, 0x5f, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x48, 0x01, 0x52, 0x16,
+	0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x88, 0x01, 0x01, 0x12, 0x51, 0x0a, 0x10, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x73, 0x62, 0x65, 0x72, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e,
+	0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x52, 0x0e, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x50, 0x65, 0x72, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x12, 0x55, 0x0a, 0x10,
+	0x62, 0x75, 0x73, 0x69, 0x65, 0x73, 0x74, 0x5f, 0x70, 0x6f, 0x73, 0x74, 0x63, 0x6f, 0x64, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x73, 0x62, 0x65, 0x72, 0x74, 0x65, 0x73,
+	0x74, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x2e, 0x42, 0x75, 0x73, 0x69, 0x65, 0x73, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x63, 0x6f,
+	0x64, 0x65, 0x52, 0x0f, 0x62, 0x75, 0x73, 0x69, 0x65, 0x73, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x63,
+	0x6f, 0x64, 0x65, 0x12, 0x6d, 0x0a, 0x1b, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x70, 0x65, 0x72,
+	0x5f, 0x70, 0x6f, 0x73, 0x74, 0x63, 0x6f, 0x64, 0x65, 0x5f, 0x61, 0x6e, 0x64, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x73, 0x62, 0x65, 0x72, 0x74,
+	0x65, 0x73, 0x74, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x63, 0x6f, 0x64, 0x65, 0x41, 0x6e, 0x64,
+	0x54, 0x69, 0x6d, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x17, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x50, 0x65, 0x72, 0x50, 0x6f, 0x73, 0x74, 0x63, 0x6f, 0x64, 0x65, 0x41, 0x6e, 0x64, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x46, 0x0a, 0x0d, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x62, 0x79, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x73, 0x62, 0x65, 0x72,
+	0x74, 0x65, 0x73, 0x74, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x0b, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x42, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x4a, 0x0a, 0x0c, 0x61, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x26, 0x2e, 0x73, 0x62, 0x65, 0x72, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x72, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x41, 0x67, 0x67,
+	0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x4f, 0x0a, 0x0e, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x64,
+	0x5f, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28,
+	0x2e, 0x73, 0x62, 0x65, 0x72, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65,
+	0x64, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x73, 0x52, 0x0d, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x64,
+	0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x73, 0x12, 0x58, 0x0a, 0x11, 0x69, 0x6e, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x5f, 0x70, 0x6f, 0x73, 0x74, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x73, 0x62, 0x65, 0x72, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x49,
+	0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x50, 0x6f, 0x73, 0x74, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x52,
+	0x10, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x50, 0x6f, 0x73, 0x74, 0x63, 0x6f, 0x64, 0x65,
+	0x73, 0x12, 0x58, 0x0a, 0x12, 0x75, 0x6e, 0x6d, 0x61, 0x70, 0x70, 0x65, 0x64, 0x5f, 0x70, 0x6f,
+	0x73, 0x74, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e,
+	0x73, 0x62, 0x65, 0x72, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x63, 0x6f,
+	0x64, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x52, 0x11, 0x75, 0x6e, 0x6d, 0x61, 0x70, 0x70,
+	0x65, 0x64, 0x50, 0x6f, 0x73, 0x74, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x57, 0x0a, 0x12, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72,
+	0x79, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x73, 0x62, 0x65, 0x72, 0x74, 0x65,
+	0x73, 0x74, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x2e, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x73, 0x52, 0x10, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x50, 0x65, 0x72, 0x43, 0x61, 0x74, 0x65,
+	0x67, 0x6f, 0x72, 0x79, 0x12, 0x48, 0x0a, 0x0d, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x70, 0x65,
+	0x72, 0x5f, 0x74, 0x61, 0x67, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x73, 0x62,
+	0x65, 0x72, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x54, 0x61, 0x67, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x73, 0x52, 0x0b, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x50, 0x65, 0x72, 0x54, 0x61, 0x67, 0x12, 0x41,
+	0x0a, 0x09, 0x74, 0x61, 0x67, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x24, 0x2e, 0x73, 0x62, 0x65, 0x72, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x54, 0x61,
+	0x67, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73, 0x52, 0x08, 0x74, 0x61, 0x67, 0x53, 0x68, 0x61, 0x72,
+	0x65, 0x12, 0x50, 0x0a, 0x0f, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x5f, 0x72, 0x65, 0x63,
+	0x69, 0x70, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x73, 0x62, 0x65,
+	0x72, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x73, 0x52, 0x0e, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x63, 0x69,
+	0x70, 0x65, 0x73, 0x1a, 0x1f, 0x0a, 0x07, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x69,
+	0x74, 0x65, 0x6d, 0x73, 0x1a, 0x3b, 0x0a, 0x0b, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x1a, 0x62, 0x0a, 0x0c, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3c, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x73, 0x62, 0x65, 0x72, 0x74, 0x65, 0x73,
+	0x74, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x2e, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x05,
+	0x69, 0x74, 0x65, 0x6d, 0x73, 0x1a, 0xa4, 0x01, 0x0a, 0x0f, 0x42, 0x75, 0x73, 0x69, 0x65, 0x73,
+	0x74, 0x50, 0x6f, 0x73, 0x74, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73,
+	0x74, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6f, 0x73,
+	0x74, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72,
+	0x79, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x64,
+	0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x35, 0x0a, 0x14,
+	0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x12, 0x64, 0x65,
+	0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x88, 0x01, 0x01, 0x42, 0x17, 0x0a, 0x15, 0x5f, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79,
+	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x1a, 0x7d, 0x0a, 0x14,
+	0x50, 0x6f, 0x73, 0x74, 0x63, 0x6f, 0x64, 0x65, 0x41, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x74, 0x63, 0x6f, 0x64, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x74, 0x63, 0x6f, 0x64, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04,
+	0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x02, 0x74, 0x6f, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79,
+	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x64, 0x65,
+	0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x1a, 0x8a, 0x02, 0x0a, 0x0b,
+	0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x5f, 0x62, 0x79, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x42, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f,
+	0x64, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x12, 0x3e, 0x0a,
+	0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x73, 0x62,
+	0x65, 0x72, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x12, 0x1c, 0x0a,
+	0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x1a, 0x5b, 0x0a, 0x03, 0x52,
+	0x6f, 0x77, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x08, 0x64, 0x69,
+	0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x08,
+	0x64, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x88, 0x01, 0x01, 0x42, 0x0b, 0x0a, 0x09, 0x5f,
+	0x64, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x1a, 0x42, 0x0a, 0x0c, 0x4d, 0x65, 0x72, 0x67,
+	0x65, 0x64, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x69,
+	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x08, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x73, 0x1a, 0x4e, 0x0a, 0x0d,
+	0x4d, 0x65, 0x72, 0x67, 0x65, 0x64, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x73, 0x12, 0x3d, 0x0a,
+	0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x73,
+	0x62, 0x65, 0x72, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x64, 0x52,
+	0x65, 0x63, 0x69, 0x70, 0x65, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x1a, 0x5b, 0x0a, 0x0f,
+	0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x50, 0x6f, 0x73, 0x74, 0x63, 0x6f, 0x64, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x74, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x74, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x1a, 0x6a, 0x0a, 0x10, 0x49, 0x6e, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x50, 0x6f, 0x73, 0x74, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x40, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x73, 0x62, 0x65, 0x72, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x49,
+	0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x50, 0x6f, 0x73, 0x74, 0x63, 0x6f, 0x64, 0x65, 0x52, 0x05,
+	0x69, 0x74, 0x65, 0x6d, 0x73, 0x1a, 0x41, 0x0a, 0x0d, 0x50, 0x6f, 0x73, 0x74, 0x63, 0x6f, 0x64,
+	0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x74, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x74, 0x63, 0x6f,
+	0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x1a, 0x66, 0x0a, 0x0e, 0x50, 0x6f, 0x73, 0x74,
+	0x63, 0x6f, 0x64, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x3e, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x28, 0x2e, 0x73, 0x62, 0x65, 0x72, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x50, 0x6f, 0x73, 0x74,
+	0x63, 0x6f, 0x64, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73,
+	0x1a, 0x5b, 0x0a, 0x0d, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x73, 0x1a, 0x50, 0x0a,
+	0x0e, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12,
+	0x3e, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28,
+	0x2e, 0x73, 0x62, 0x65, 0x72, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x43, 0x61, 0x74, 0x65, 0x67,
+	0x6f, 0x72, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x1a,
+	0x32, 0x0a, 0x08, 0x54, 0x61, 0x67, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x74,
+	0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x1a, 0x46, 0x0a, 0x09, 0x54, 0x61, 0x67, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73,
+	0x12, 0x39, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x23, 0x2e, 0x73, 0x62, 0x65, 0x72, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x54, 0x61, 0x67, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x1a, 0x7a, 0x0a, 0x08, 0x54,
+	0x61, 0x67, 0x53, 0x68, 0x61, 0x72, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65, 0x6c,
+	0x69, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0d, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x68, 0x61, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x05, 0x73, 0x68, 0x61, 0x72, 0x65, 0x1a, 0x46, 0x0a, 0x09, 0x54, 0x61, 0x67, 0x53, 0x68,
+	0x61, 0x72, 0x65, 0x73, 0x12, 0x39, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x73, 0x62, 0x65, 0x72, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e,
+	0x54, 0x61, 0x67, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x42,
+	0x16, 0x0a, 0x14, 0x5f, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x5f, 0x72, 0x65, 0x63, 0x69, 0x70,
+	0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x1c, 0x0a, 0x1a, 0x5f, 0x75, 0x6e, 0x69, 0x71,
+	0x75, 0x65, 0x5f, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x2a, 0x9d, 0x01, 0x0a, 0x07, 0x57, 0x65, 0x65, 0x6b, 0x64, 0x61,
+	0x79, 0x12, 0x12, 0x0a, 0x0e, 0x57, 0x45, 0x45, 0x4b, 0x44, 0x41, 0x59, 0x5f, 0x53, 0x55, 0x4e,
+	0x44, 0x41, 0x59, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x57, 0x45, 0x45, 0x4b, 0x44, 0x41, 0x59,
+	0x5f, 0x4d, 0x4f, 0x4e, 0x44, 0x41, 0x59, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x57, 0x45, 0x45,
+	0x4b, 0x44, 0x41, 0x59, 0x5f, 0x54, 0x55, 0x45, 0x53, 0x44, 0x41, 0x59, 0x10, 0x02, 0x12, 0x15,
+	0x0a, 0x11, 0x57, 0x45, 0x45, 0x4b, 0x44, 0x41, 0x59, 0x5f, 0x57, 0x45, 0x44, 0x4e, 0x45, 0x53,
+	0x44, 0x41, 0x59, 0x10, 0x03, 0x12, 0x14, 0x0a, 0x10, 0x57, 0x45, 0x45, 0x4b, 0x44, 0x41, 0x59,
+	0x5f, 0x54, 0x48, 0x55, 0x52, 0x53, 0x44, 0x41, 0x59, 0x10, 0x04, 0x12, 0x12, 0x0a, 0x0e, 0x57,
+	0x45, 0x45, 0x4b, 0x44, 0x41, 0x59, 0x5f, 0x46, 0x52, 0x49, 0x44, 0x41, 0x59, 0x10, 0x05, 0x12,
+	0x14, 0x0a, 0x10, 0x57, 0x45, 0x45, 0x4b, 0x44, 0x41, 0x59, 0x5f, 0x53, 0x41, 0x54, 0x55, 0x52,
+	0x44, 0x41, 0x59, 0x10, 0x06, 0x32, 0xb2, 0x01, 0x0a, 0x0d, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4a, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x12, 0x21, 0x2e, 0x73, 0x62, 0x65, 0x72, 0x74, 0x65, 0x73, 0x74, 0x2e,
+	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x73, 0x62, 0x65, 0x72, 0x74, 0x65,
+	0x73, 0x74, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x12, 0x55, 0x0a, 0x0c, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x12, 0x27, 0x2e, 0x73, 0x62, 0x65, 0x72, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x73,
+	0x62, 0x65, 0x72, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x28, 0x01, 0x42, 0x1c, 0x5a, 0x1a, 0x73, 0x62,
+	0x65, 0x72, 0x2d, 0x74, 0x65, 0x73, 0x74, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x72, 0x70, 0x63, 0x2f,
+	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_report_proto_rawDescOnce sync.Once
+	file_report_proto_rawDescData = file_report_proto_rawDesc
+)
+
+func file_report_proto_rawDescGZIP() []byte {
+	file_report_proto_rawDescOnce.Do(func() {
+		file_report_proto_rawDescData = protoimpl.X.CompressGZIP(file_report_proto_rawDescData)
+	})
+	return file_report_proto_rawDescData
+}
+
+var file_report_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_report_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_report_proto_goTypes = []any{
+	(Weekday)(0),                          // 0: sbertest.report.v1.Weekday
+	(*DeliverySlot)(nil),                  // 1: sbertest.report.v1.DeliverySlot
+	(*RecipeDelivery)(nil),                // 2: sbertest.report.v1.RecipeDelivery
+	(*RecipeDeliveries)(nil),              // 3: sbertest.report.v1.RecipeDeliveries
+	(*ReportRequest)(nil),                 // 4: sbertest.report.v1.ReportRequest
+	(*StreamReportRequest)(nil),           // 5: sbertest.report.v1.StreamReportRequest
+	(*Report)(nil),                        // 6: sbertest.report.v1.Report
+	(*ReportRequest_PostcodeAndTime)(nil), // 7: sbertest.report.v1.ReportRequest.PostcodeAndTime
+	(*ReportRequest_GroupBy)(nil),         // 8: sbertest.report.v1.ReportRequest.GroupBy
+	(*Report_Strings)(nil),                // 9: sbertest.report.v1.Report.Strings
+	(*Report_RecipeCount)(nil),            // 10: sbertest.report.v1.Report.RecipeCount
+	(*Report_RecipeCounts)(nil),           // 11: sbertest.report.v1.Report.RecipeCounts
+	(*Report_BusiestPostcode)(nil),        // 12: sbertest.report.v1.Report.BusiestPostcode
+	(*Report_PostcodeAndTimeCount)(nil),   // 13: sbertest.report.v1.Report.PostcodeAndTimeCount
+	(*Report_Aggregation)(nil),            // 14: sbertest.report.v1.Report.Aggregation
+	(*Report_MergedRecipe)(nil),           // 15: sbertest.report.v1.Report.MergedRecipe
+	(*Report_MergedRecipes)(nil),          // 16: sbertest.report.v1.Report.MergedRecipes
+	(*Report_InvalidPostcode)(nil),        // 17: sbertest.report.v1.Report.InvalidPostcode
+	(*Report_InvalidPostcodes)(nil),       // 18: sbertest.report.v1.Report.InvalidPostcodes
+	(*Report_PostcodeCount)(nil),          // 19: sbertest.report.v1.Report.PostcodeCount
+	(*Report_PostcodeCounts)(nil),         // 20: sbertest.report.v1.Report.PostcodeCounts
+	(*Report_CategoryCount)(nil),          // 21: sbertest.report.v1.Report.CategoryCount
+	(*Report_CategoryCounts)(nil),         // 22: sbertest.report.v1.Report.CategoryCounts
+	(*Report_TagCount)(nil),               // 23: sbertest.report.v1.Report.TagCount
+	(*Report_TagCounts)(nil),              // 24: sbertest.report.v1.Report.TagCounts
+	(*Report_TagShare)(nil),               // 25: sbertest.report.v1.Report.TagShare
+	(*Report_TagShares)(nil),              // 26: sbertest.report.v1.Report.TagShares
+	(*Report_Aggregation_Row)(nil),        // 27: sbertest.report.v1.Report.Aggregation.Row
+}
+var file_report_proto_depIdxs = []int32{
+	0,  // 0: sbertest.report.v1.DeliverySlot.weekday:type_name -> sbertest.report.v1.Weekday
+	1,  // 1: sbertest.report.v1.RecipeDelivery.delivery:type_name -> sbertest.report.v1.DeliverySlot
+	2,  // 2: sbertest.report.v1.RecipeDeliveries.items:type_name -> sbertest.report.v1.RecipeDelivery
+	7,  // 3: sbertest.report.v1.ReportRequest.deliveries_by_postcode_and_time:type_name -> sbertest.report.v1.ReportRequest.PostcodeAndTime
+	8,  // 4: sbertest.report.v1.ReportRequest.group_by:type_name -> sbertest.report.v1.ReportRequest.GroupBy
+	4,  // 5: sbertest.report.v1.StreamReportRequest.request:type_name -> sbertest.report.v1.ReportRequest
+	3,  // 6: sbertest.report.v1.StreamReportRequest.deliveries:type_name -> sbertest.report.v1.RecipeDeliveries
+	11, // 7: sbertest.report.v1.Report.count_per_recipe:type_name -> sbertest.report.v1.Report.RecipeCounts
+	12, // 8: sbertest.report.v1.Report.busiest_postcode:type_name -> sbertest.report.v1.Report.BusiestPostcode
+	13, // 9: sbertest.report.v1.Report.count_per_postcode_and_time:type_name -> sbertest.report.v1.Report.PostcodeAndTimeCount
+	9,  // 10: sbertest.report.v1.Report.match_by_name:type_name -> sbertest.report.v1.Report.Strings
+	14, // 11: sbertest.report.v1.Report.aggregations:type_name -> sbertest.report.v1.Report.Aggregation
+	16, // 12: sbertest.report.v1.Report.merged_recipes:type_name -> sbertest.report.v1.Report.MergedRecipes
+	18, // 13: sbertest.report.v1.Report.invalid_postcodes:type_name -> sbertest.report.v1.Report.InvalidPostcodes
+	20, // 14: sbertest.report.v1.Report.unmapped_postcodes:type_name -> sbertest.report.v1.Report.PostcodeCounts
+	22, // 15: sbertest.report.v1.Report.count_per_category:type_name -> sbertest.report.v1.Report.CategoryCounts
+	24, // 16: sbertest.report.v1.Report.count_per_tag:type_name -> sbertest.report.v1.Report.TagCounts
+	26, // 17: sbertest.report.v1.Report.tag_share:type_name -> sbertest.report.v1.Report.TagShares
+	11, // 18: sbertest.report.v1.Report.unknown_recipes:type_name -> sbertest.report.v1.Report.RecipeCounts
+	10, // 19: sbertest.report.v1.Report.RecipeCounts.items:type_name -> sbertest.report.v1.Report.RecipeCount
+	27, // 20: sbertest.report.v1.Report.Aggregation.rows:type_name -> sbertest.report.v1.Report.Aggregation.Row
+	15, // 21: sbertest.report.v1.Report.MergedRecipes.items:type_name -> sbertest.report.v1.Report.MergedRecipe
+	17, // 22: sbertest.report.v1.Report.InvalidPostcodes.items:type_name -> sbertest.report.v1.Report.InvalidPostcode
+	19, // 23: sbertest.report.v1.Report.PostcodeCounts.items:type_name -> sbertest.report.v1.Report.PostcodeCount
+	21, // 24: sbertest.report.v1.Report.CategoryCounts.items:type_name -> sbertest.report.v1.Report.CategoryCount
+	23, // 25: sbertest.report.v1.Report.TagCounts.items:type_name -> sbertest.report.v1.Report.TagCount
+	25, // 26: sbertest.report.v1.Report.TagShares.items:type_name -> sbertest.report.v1.Report.TagShare
+	4,  // 27: sbertest.report.v1.ReportService.GetReport:input_type -> sbertest.report.v1.ReportRequest
+	5,  // 28: sbertest.report.v1.ReportService.StreamReport:input_type -> sbertest.report.v1.StreamReportRequest
+	6,  // 29: sbertest.report.v1.ReportService.GetReport:output_type -> sbertest.report.v1.Report
+	6,  // 30: sbertest.report.v1.ReportService.StreamReport:output_type -> sbertest.report.v1.Report
+	29, // [29:31] is the sub-list for method output_type
+	27, // [27:29] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
+}
+
+func init() { file_report_proto_init() }
+func file_report_proto_init() {
+	if File_report_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_report_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*DeliverySlot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_report_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*RecipeDelivery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_report_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*RecipeDeliveries); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_report_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*ReportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_report_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*StreamReportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_report_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*Report); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_report_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*ReportRequest_PostcodeAndTime); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_report_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*ReportRequest_GroupBy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_report_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*Report_Strings); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_report_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*Report_RecipeCount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_report_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*Report_RecipeCounts); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_report_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*Report_BusiestPostcode); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_report_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*Report_PostcodeAndTimeCount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_report_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*Report_Aggregation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_report_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*Report_MergedRecipe); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_report_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*Report_MergedRecipes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_report_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*Report_InvalidPostcode); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_report_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*Report_InvalidPostcodes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_report_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*Report_PostcodeCount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_report_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*Report_PostcodeCounts); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_report_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*Report_CategoryCount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_report_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*Report_CategoryCounts); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_report_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*Report_TagCount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_report_proto_msgTypes[23].Exporter = func(v any, i int) any {
+			switch v := v.(*Report_TagCounts); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_report_proto_msgTypes[24].Exporter = func(v any, i int) any {
+			switch v := v.(*Report_TagShare); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_report_proto_msgTypes[25].Exporter = func(v any, i int) any {
+			switch v := v.(*Report_TagShares); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_report_proto_msgTypes[26].Exporter = func(v any, i int) any {
+			switch v := v.(*Report_Aggregation_Row); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_report_proto_msgTypes[4].OneofWrappers = []any{
+		(*StreamReportRequest_Request)(nil),
+		(*StreamReportRequest_Deliveries)(nil),
+	}
+	file_report_proto_msgTypes[5].OneofWrappers = []any{}
+	file_report_proto_msgTypes[11].OneofWrappers = []any{}
+	file_report_proto_msgTypes[26].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_report_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   27,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_report_proto_goTypes,
+		DependencyIndexes: file_report_proto_depIdxs,
+		EnumInfos:         file_report_proto_enumTypes,
+		MessageInfos:      file_report_proto_msgTypes,
+	}.Build()
+	File_report_proto = out.File
+	file_report_proto_rawDesc = nil
+	file_report_proto_goTypes = nil
+	file_report_proto_depIdxs = nil
+}
@@ -0,0 +1,187 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: report.proto
+
+package reportpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ReportService_GetReport_FullMethodName    = "/sbertest.report.v1.ReportService/GetReport"
+	ReportService_StreamReport_FullMethodName = "/sbertest.report.v1.ReportService/StreamReport"
+)
+
+// ReportServiceClient is the client API for ReportService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReportServiceClient interface {
+	// GetReport отчёт по источнику доставок, подключенному к серверу
+	GetReport(ctx context.Context, in *ReportRequest, opts ...grpc.CallOption) (*Report, error)
+	// StreamReport отчёт по доставкам клиента: первое сообщение потока - ReportRequest,
+	// следующие - пачки доставок; отчёт возвращается после закрытия потока клиентом
+	StreamReport(ctx context.Context, opts ...grpc.CallOption) (ReportService_StreamReportClient, error)
+}
+
+type reportServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReportServiceClient(cc grpc.ClientConnInterface) ReportServiceClient {
+	return &reportServiceClient{cc}
+}
+
+func (c *reportServiceClient) GetReport(ctx context.Context, in *ReportRequest, opts ...grpc.CallOption) (*Report, error) {
+	out := new(Report)
+	err := c.cc.Invoke(ctx, ReportService_GetReport_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reportServiceClient) StreamReport(ctx context.Context, opts ...grpc.CallOption) (ReportService_StreamReportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ReportService_ServiceDesc.Streams[0], ReportService_StreamReport_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &reportServiceStreamReportClient{stream}
+	return x, nil
+}
+
+type ReportService_StreamReportClient interface {
+	Send(*StreamReportRequest) error
+	CloseAndRecv() (*Report, error)
+	grpc.ClientStream
+}
+
+type reportServiceStreamReportClient struct {
+	grpc.ClientStream
+}
+
+func (x *reportServiceStreamReportClient) Send(m *StreamReportRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *reportServiceStreamReportClient) CloseAndRecv() (*Report, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(Report)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ReportServiceServer is the server API for ReportService service.
+// All implementations must embed UnimplementedReportServiceServer
+// for forward compatibility
+type ReportServiceServer interface {
+	// GetReport отчёт по источнику доставок, подключенному к серверу
+	GetReport(context.Context, *ReportRequest) (*Report, error)
+	// StreamReport отчёт по доставкам клиента: первое сообщение потока - ReportRequest,
+	// следующие - пачки доставок; отчёт возвращается после закрытия потока клиентом
+	StreamReport(ReportService_StreamReportServer) error
+	mustEmbedUnimplementedReportServiceServer()
+}
+
+// UnimplementedReportServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedReportServiceServer struct {
+}
+
+func (UnimplementedReportServiceServer) GetReport(context.Context, *ReportRequest) (*Report, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReport not implemented")
+}
+func (UnimplementedReportServiceServer) StreamReport(ReportService_StreamReportServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamReport not implemented")
+}
+func (UnimplementedReportServiceServer) mustEmbedUnimplementedReportServiceServer() {}
+
+// UnsafeReportServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReportServiceServer will
+// result in compilation errors.
+type UnsafeReportServiceServer interface {
+	mustEmbedUnimplementedReportServiceServer()
+}
+
+func RegisterReportServiceServer(s grpc.ServiceRegistrar, srv ReportServiceServer) {
+	s.RegisterService(&ReportService_ServiceDesc, srv)
+}
+
+func _ReportService_GetReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportServiceServer).GetReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReportService_GetReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportServiceServer).GetReport(ctx, req.(*ReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReportService_StreamReport_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ReportServiceServer).StreamReport(&reportServiceStreamReportServer{stream})
+}
+
+type ReportService_StreamReportServer interface {
+	SendAndClose(*Report) error
+	Recv() (*StreamReportRequest, error)
+	grpc.ServerStream
+}
+
+type reportServiceStreamReportServer struct {
+	grpc.ServerStream
+}
+
+func (x *reportServiceStreamReportServer) SendAndClose(m *Report) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *reportServiceStreamReportServer) Recv() (*StreamReportRequest, error) {
+	m := new(StreamReportRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ReportService_ServiceDesc is the grpc.ServiceDesc for ReportService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReportService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "sbertest.report.v1.ReportService",
+	HandlerType: (*ReportServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetReport",
+			Handler:    _ReportService_GetReport_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamReport",
+			Handler:       _ReportService_StreamReport_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "report.proto",
+}
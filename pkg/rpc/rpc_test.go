@@ -0,0 +1,158 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert" //nolint:goimports
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"sber-test/pkg/models"
+	"sber-test/pkg/processors"
+	"sber-test/pkg/providers"
+	"sber-test/pkg/rpc/reportpb"
+	ts "sber-test/pkg/time-slot" //nolint:goimports
+)
+
+var testData = providers.RecipeDeliveries{
+	{Recipe: "Ink | Pen", Postcode: "1", Delivery: ts.ConstructDelivery(time.Monday, 10, 15)},
+	{Recipe: "Ink | Pen", Postcode: "1", Delivery: ts.ConstructDelivery(time.Thursday, 10, 15)},
+	{Recipe: "B Potato", Postcode: "2", Delivery: ts.ConstructDelivery(time.Wednesday, 8, 15)},
+}
+
+// dial клиент сервиса srv поверх bufconn
+func dial(t *testing.T, srv reportpb.ReportServiceServer) *Client {
+	ln := bufconn.Listen(1 << 20)
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- Serve(ctx, ln, srv, time.Second)
+	}()
+	cc, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return ln.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		_ = cc.Close()
+		cancel()
+		assert.NoError(t, <-served)
+	})
+	return NewClient(cc)
+}
+
+var testRequest = &reportpb.ReportRequest{
+	CountPerRecipe:              true,
+	UniqueRecipeCount:           true,
+	BusiestPostcode:             true,
+	MatchByName:                 []string{"Pen"},
+	DeliveriesByPostcodeAndTime: &reportpb.ReportRequest_PostcodeAndTime{Postcode: "1", From: 9, To: 16},
+	GroupBy:                     []*reportpb.ReportRequest_GroupBy{{By: []string{"weekday"}, OrderBy: []string{"count:desc"}, Limit: 1}},
+}
+
+func TestReport(t *testing.T) {
+	c := dial(t, NewServer(testData, nil))
+	got, err := c.Report(context.Background(), testRequest)
+	assert.NoError(t, err)
+
+	subjects, err := SubjectsFromRequest(testRequest)
+	assert.NoError(t, err)
+	want, err := processors.NewRecipeReportProcessor(subjects[0], subjects[1:]...).Process(context.Background(), testData)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(ReportToProto(want), got), "%v", got)
+
+	assert.Equal(t, int64(2), got.GetUniqueRecipeCount())
+	assert.Equal(t, "1", got.GetBusiestPostcode().GetPostcode())
+	assert.Equal(t, int64(3), got.GetCountPerRecipe().GetCount())
+	assert.Equal(t, []string{"Ink | Pen"}, got.GetMatchByName().GetItems())
+	assert.Equal(t, int64(2), got.GetCountPerPostcodeAndTime().GetDeliveryCount())
+	assert.Len(t, got.GetAggregations(), 1)
+	assert.Nil(t, got.UniqueRecipeCountError)
+}
+
+func TestReportErrors(t *testing.T) {
+	c := dial(t, NewServer(testData, nil))
+	for _, req := range []*reportpb.ReportRequest{
+		{},
+		{Approximate: 1},
+		{UniqueRecipeCount: true, Approximate: 1e-9},
+		{CountPerRecipe: true, Approximate: 0.01},
+		{DeliveriesByPostcodeAndTime: &reportpb.ReportRequest_PostcodeAndTime{Postcode: "1", From: 16, To: 9}},
+		{GroupBy: []*reportpb.ReportRequest_GroupBy{{By: []string{"nope"}}}},
+	} {
+		_, err := c.Report(context.Background(), req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(errors.Cause(err)), "%v", req)
+	}
+	for _, req := range []*reportpb.ReportRequest{
+		{CountPerCategory: true},
+		{TagShare: []string{"vegan"}},
+		{GroupBy: []*reportpb.ReportRequest_GroupBy{{By: []string{"zone"}}}},
+	} {
+		_, err := c.Report(context.Background(), req)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(errors.Cause(err)), "%v", req)
+	}
+	_, err := dial(t, NewServer(nil, nil)).Report(context.Background(), testRequest)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(errors.Cause(err)))
+}
+
+func TestReportStages(t *testing.T) {
+	var calls int
+	c := dial(t, NewServer(testData, func() []processors.RecipeDeliveryStage {
+		calls++
+		regions, err := processors.EnrichRegions(map[string]models.Region{"1": {Zone: "North"}}, processors.DimNone)
+		assert.NoError(t, err)
+		catalog, err := processors.JoinCatalog([]models.CatalogRecipe{{Name: "Ink | Pen", Category: "office"}})
+		assert.NoError(t, err)
+		return []processors.RecipeDeliveryStage{regions, catalog}
+	}))
+	req := &reportpb.ReportRequest{
+		CountPerCategory: true,
+		GroupBy:          []*reportpb.ReportRequest_GroupBy{{By: []string{"zone"}}},
+	}
+	for i := 0; i < 2; i++ {
+		got, err := c.Report(context.Background(), req)
+		assert.NoError(t, err)
+		// стадии создаются на каждый запрос, счётчики не накапливаются между запросами
+		assert.Equal(t, int64(1), got.GetUnmappedPostcodes().GetCount())
+		assert.Equal(t, int64(1), got.GetUnknownRecipes().GetCount())
+		assert.Equal(t, "office", got.GetCountPerCategory().GetItems()[0].GetCategory())
+	}
+	assert.Equal(t, 2, calls)
+}
+
+func TestStreamReport(t *testing.T) {
+	c := dial(t, NewServer(nil, nil))
+	got, err := c.StreamReport(context.Background(), testRequest, testData, 2)
+	assert.NoError(t, err)
+	remote, err := dial(t, NewServer(testData, nil)).Report(context.Background(), testRequest)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(remote, got), "%v", got)
+
+	got, err = c.StreamReport(context.Background(), &reportpb.ReportRequest{UniqueRecipeCount: true}, providers.RecipeDeliveries{}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), got.GetUniqueRecipeCount())
+	assert.NotNil(t, got.UniqueRecipeCount)
+}
+
+func TestStreamReportBadDelivery(t *testing.T) {
+	c := dial(t, NewServer(nil, nil))
+	bad := providers.RecipeDeliveries{{Recipe: "X", Postcode: "1", Delivery: ts.ConstructDelivery(time.Monday, 10, 25)}}
+	_, err := c.StreamReport(context.Background(), testRequest, bad, 0)
+	assert.Equal(t, codes.InvalidArgument, status.Code(errors.Cause(err)))
+}
+
+func TestDeliveryRoundTrip(t *testing.T) {
+	for _, item := range testData {
+		got, err := DeliveryFromProto(DeliveryToProto(item))
+		assert.NoError(t, err)
+		assert.Equal(t, item, got)
+	}
+}
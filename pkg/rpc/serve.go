@@ -0,0 +1,42 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/pkg/errors" //nolint:goimports
+	"google.golang.org/grpc"
+	"sber-test/pkg/rpc/reportpb" //nolint:goimports
+)
+
+// Serve обслуживает ReportService на ln, пока не отменён ctx; затем перестаёт принимать
+// соединения и ждёт завершения начатых вызовов не дольше shutdownTimeout
+func Serve(ctx context.Context, ln net.Listener, srv reportpb.ReportServiceServer, shutdownTimeout time.Duration) error {
+	const api = "rpc.Serve"
+
+	s := grpc.NewServer()
+	reportpb.RegisterReportServiceServer(s, srv)
+	served := make(chan error, 1)
+	go func() {
+		served <- s.Serve(ln)
+	}()
+	select {
+	case err := <-served:
+		return errors.Wrap(err, api)
+	case <-ctx.Done():
+	}
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		s.Stop()
+		<-stopped
+		return errors.Errorf("%s: in-flight calls were not finished in %v", api, shutdownTimeout)
+	}
+	return errors.Wrap(<-served, api)
+}
@@ -0,0 +1,121 @@
+// Package rpc gRPC-сервис отчётов ReportService (см. reportpb/report.proto) и его клиент
+package rpc
+
+import (
+	"context"
+	"io"
+
+	"github.com/pkg/errors" //nolint:goimports
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sber-test/pkg/models"
+	"sber-test/pkg/processors"
+	"sber-test/pkg/providers"
+	"sber-test/pkg/rpc/reportpb" //nolint:goimports
+)
+
+// NewServer ReportService поверх RecipeReportProcessor; GetReport читает provider, StreamReport -
+// доставки клиента; provider может быть nil, тогда доступен только StreamReport. Стадии накапливают
+// состояние, поэтому stages вызывается на каждый запрос; stages может быть nil
+func NewServer(provider providers.RecipeDeliveryProvider, stages func() []processors.RecipeDeliveryStage) reportpb.ReportServiceServer {
+	return &server{provider: provider, stages: stages}
+}
+
+// ---------------------------------------- IMPL -------------------------------------
+
+type (
+	server struct {
+		reportpb.UnimplementedReportServiceServer
+		provider providers.RecipeDeliveryProvider
+		stages   func() []processors.RecipeDeliveryStage
+	}
+
+	// streamProvider доставки из клиентского потока StreamReport
+	streamProvider struct {
+		stream reportpb.ReportService_StreamReportServer
+	}
+)
+
+// GetReport ...
+func (s *server) GetReport(ctx context.Context, req *reportpb.ReportRequest) (*reportpb.Report, error) {
+	if s.provider == nil {
+		return nil, status.Error(codes.FailedPrecondition, "server has no source of deliveries; use StreamReport")
+	}
+	return s.report(ctx, req, s.provider)
+}
+
+// StreamReport ...
+func (s *server) StreamReport(stream reportpb.ReportService_StreamReportServer) error {
+	msg, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		return status.Error(codes.InvalidArgument, "stream is empty; expected report request")
+	}
+	if err != nil {
+		return err
+	}
+	req := msg.GetRequest()
+	if req == nil {
+		return status.Error(codes.InvalidArgument, "first message of stream must be report request")
+	}
+	report, err := s.report(stream.Context(), req, streamProvider{stream: stream})
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(report)
+}
+
+func (s *server) report(ctx context.Context, req *reportpb.ReportRequest, provider providers.RecipeDeliveryProvider) (*reportpb.Report, error) {
+	subjects, err := SubjectsFromRequest(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var stages []processors.RecipeDeliveryStage
+	if s.stages != nil {
+		stages = s.stages()
+	}
+	if needsCatalog(req) && !processors.HasCatalog(stages...) {
+		return nil, status.Error(codes.FailedPrecondition, "server has no recipe catalog")
+	}
+	if needsRegions(req) && !processors.HasRegions(stages...) {
+		return nil, status.Error(codes.FailedPrecondition, "server has no regions table")
+	}
+	report, err := processors.NewRecipeReportProcessor(subjects[0], subjects[1:]...).
+		WithStages(stages...).
+		Process(ctx, provider)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.FromContextError(err).Err()
+	}
+	return ReportToProto(report), nil
+}
+
+// Provide ...
+func (p streamProvider) Provide(ctx context.Context, consumer func(models.RecipeDelivery) error) error {
+	for {
+		msg, err := p.stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		batch := msg.GetDeliveries()
+		if batch == nil {
+			return status.Error(codes.InvalidArgument, "report request may be sent only once; expected deliveries")
+		}
+		for _, item := range batch.GetItems() {
+			if err = ctx.Err(); err != nil {
+				return err
+			}
+			d, err := DeliveryFromProto(item)
+			if err != nil {
+				return status.Error(codes.InvalidArgument, err.Error())
+			}
+			if err = consumer(d); err != nil {
+				return err
+			}
+		}
+	}
+}
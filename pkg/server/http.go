@@ -0,0 +1,153 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors" //nolint:goimports
+	"sber-test/pkg/processors"
+	"sber-test/pkg/providers"
+	ts "sber-test/pkg/time-slot" //nolint:goimports
+)
+
+// NewHandler REST API отчётов; каждый запрос строит отчёт одним проходом по provider:
+//
+//	GET /recipes/counts                             count_per_recipe
+//	GET /recipes/unique                             unique_recipe_count
+//	GET /recipes/search?q=Potato&q=Veggie           match_by_name (q можно перечислить и через запятую)
+//	GET /postcodes/busiest                          busiest_postcode
+//	GET /postcodes/{pc}/deliveries?from=10AM&to=3PM count_per_postcode_and_time (по умолчанию весь день)
+//	GET /healthz
+//
+// Ответ - RecipeProcessorReport с единственным разделом, ошибка - {"error": "..."}. Стадии накапливают
+// состояние, поэтому stages вызывается на каждый запрос; stages может быть nil
+func NewHandler(provider providers.RecipeDeliveryProvider, stages func() []processors.RecipeDeliveryStage) http.Handler {
+	h := &handler{provider: provider, stages: stages}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", h.healthz)
+	mux.HandleFunc("/recipes/counts", h.get(h.recipeCounts))
+	mux.HandleFunc("/recipes/unique", h.get(h.uniqueRecipes))
+	mux.HandleFunc("/recipes/search", h.get(h.searchRecipes))
+	mux.HandleFunc("/postcodes/busiest", h.get(h.busiestPostcode))
+	mux.HandleFunc("/postcodes/", h.get(h.postcodeDeliveries))
+	return mux
+}
+
+// ---------------------------------------- IMPL -------------------------------------
+
+type (
+	handler struct {
+		provider providers.RecipeDeliveryProvider
+		stages   func() []processors.RecipeDeliveryStage
+	}
+
+	// subjectFunc разбирает запрос в subject отчёта
+	subjectFunc func(*http.Request) (processors.RecipeReportSubj, error)
+
+	// httpError ошибка с HTTP-статусом
+	httpError struct {
+		status int
+		error
+	}
+)
+
+func badRequest(err error) error {
+	return httpError{status: http.StatusBadRequest, error: err}
+}
+
+func (h *handler) healthz(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (h *handler) get(subject subjectFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			writeError(w, httpError{status: http.StatusMethodNotAllowed, error: errors.New("method not allowed")})
+			return
+		}
+		subj, err := subject(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		var stages []processors.RecipeDeliveryStage
+		if h.stages != nil {
+			stages = h.stages()
+		}
+		report, err := processors.NewRecipeReportProcessor(subj).
+			WithStages(stages...).
+			Process(r.Context(), h.provider)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, report)
+	}
+}
+
+func (h *handler) recipeCounts(*http.Request) (processors.RecipeReportSubj, error) {
+	return processors.ReportCounterPerRecipe(), nil
+}
+
+func (h *handler) uniqueRecipes(*http.Request) (processors.RecipeReportSubj, error) {
+	return processors.ReportUniqueRecipes(), nil
+}
+
+func (h *handler) busiestPostcode(*http.Request) (processors.RecipeReportSubj, error) {
+	return processors.ReportBusiestPostcode(), nil
+}
+
+func (h *handler) searchRecipes(r *http.Request) (processors.RecipeReportSubj, error) {
+	var names []string
+	for _, q := range r.URL.Query()["q"] {
+		for _, name := range strings.Split(q, ",") {
+			if name = strings.TrimSpace(name); len(name) > 0 {
+				names = append(names, name)
+			}
+		}
+	}
+	if len(names) == 0 {
+		return nil, badRequest(errors.New("'q' param is not provided"))
+	}
+	return processors.ReportIfMatchedRecipes(names[0], names[1:]...), nil
+}
+
+func (h *handler) postcodeDeliveries(r *http.Request) (processors.RecipeReportSubj, error) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/postcodes/"), "/")
+	if len(parts) != 2 || len(parts[0]) == 0 || parts[1] != "deliveries" {
+		return nil, httpError{status: http.StatusNotFound, error: errors.Errorf("no route for '%s'", r.URL.Path)}
+	}
+	from, to := ts.Hour(0), ts.Hour(23)
+	query := r.URL.Query()
+	for _, p := range []struct {
+		name string
+		h    *ts.Hour
+	}{{"from", &from}, {"to", &to}} {
+		if v := query.Get(p.name); len(v) > 0 {
+			if err := p.h.FromString([]byte(v)); err != nil {
+				return nil, badRequest(errors.Wrapf(err, "'%s' param has wrong value", p.name))
+			}
+		}
+	}
+	if to < from {
+		return nil, badRequest(errors.New("'from' param is after 'to'"))
+	}
+	return processors.ReportDeliveryCountForPostcodeAndTime(parts[0], from, to), nil
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var he httpError
+	if errors.As(err, &he) {
+		status = he.status
+	}
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
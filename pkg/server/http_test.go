@@ -0,0 +1,131 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" //nolint:goimports
+	"sber-test/pkg/models"
+	"sber-test/pkg/processors"
+	"sber-test/pkg/providers"
+	ts "sber-test/pkg/time-slot" //nolint:goimports
+)
+
+var testData = providers.RecipeDeliveries{
+	{Recipe: "Ink | Pen", Postcode: "1", Delivery: ts.ConstructDelivery(time.Monday, 10, 15)},
+	{Recipe: "Ink | Pen", Postcode: "1", Delivery: ts.ConstructDelivery(time.Thursday, 10, 15)},
+	{Recipe: "B Potato", Postcode: "2", Delivery: ts.ConstructDelivery(time.Wednesday, 8, 15)},
+}
+
+func get(t *testing.T, h http.Handler, method, url string) (int, string) {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(method, url, nil))
+	return w.Code, strings.TrimSpace(w.Body.String())
+}
+
+func TestHandler(t *testing.T) {
+	h := NewHandler(testData, nil)
+	for _, c := range []struct {
+		url, body string
+		status    int
+	}{
+		{"/recipes/counts", `{"count_per_recipe":[{"recipe":"B Potato","count":1},{"recipe":"Ink | Pen","count":2}]}`, 200},
+		{"/recipes/unique", `{"unique_recipe_count":2}`, 200},
+		{"/recipes/search?q=Pen&q=Nope", `{"match_by_name":["Ink | Pen"]}`, 200},
+		{"/recipes/search?q=Nope", `{"match_by_name":[]}`, 200},
+		{"/recipes/search?q=+,", `{"error":"'q' param is not provided"}`, 400},
+		{"/postcodes/busiest", `{"busiest_postcode":{"postcode":"1","delivery_count":2}}`, 200},
+		{"/postcodes/1/deliveries?from=9AM&to=4PM",
+			`{"count_per_postcode_and_time":{"postcode":"1","from":"9AM","to":"4PM","delivery_count":2}}`, 200},
+		{"/postcodes/2/deliveries",
+			`{"count_per_postcode_and_time":{"postcode":"2","from":"12AM","to":"11PM","delivery_count":1}}`, 200},
+		{"/postcodes/2/deliveries?from=5PM&to=9AM", `{"error":"'from' param is after 'to'"}`, 400},
+		{"/postcodes/2/deliveries?to=25", "", 400},
+		{"/postcodes/2", "", 404},
+		{"/healthz", `{"status":"ok"}`, 200},
+	} {
+		status, body := get(t, h, http.MethodGet, c.url)
+		assert.Equal(t, c.status, status, c.url)
+		if len(c.body) > 0 {
+			assert.Equal(t, c.body, body, c.url)
+		}
+	}
+	status, _ := get(t, h, http.MethodPost, "/recipes/counts")
+	assert.Equal(t, http.StatusMethodNotAllowed, status)
+}
+
+type failingProvider struct{}
+
+func (failingProvider) Provide(context.Context, func(models.RecipeDelivery) error) error {
+	return io.ErrUnexpectedEOF
+}
+
+func TestHandlerProviderError(t *testing.T) {
+	status, body := get(t, NewHandler(failingProvider{}, nil), http.MethodGet, "/recipes/counts")
+	assert.Equal(t, http.StatusInternalServerError, status)
+	assert.Contains(t, body, "unexpected EOF")
+}
+
+func TestHandlerStages(t *testing.T) {
+	h := NewHandler(testData, func() []processors.RecipeDeliveryStage {
+		return []processors.RecipeDeliveryStage{
+			processors.ValidatePostcodes(processors.PostcodeFormat(regexp.MustCompile(`^1$`))),
+		}
+	})
+	// стадии создаются на каждый запрос: счётчики не копятся между запросами
+	_, first := get(t, h, http.MethodGet, "/recipes/counts")
+	_, second := get(t, h, http.MethodGet, "/recipes/counts")
+	assert.Contains(t, first, `"invalid_postcodes":{"count":1`)
+	assert.Equal(t, first, second)
+}
+
+// slowHandler отвечает только после закрытия release
+type slowHandler struct {
+	started, release chan struct{}
+}
+
+func (h slowHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	close(h.started)
+	<-h.release
+	_, _ = io.WriteString(w, "done")
+}
+
+func TestServeGracefulShutdown(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	h := slowHandler{started: make(chan struct{}), release: make(chan struct{})}
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- Serve(ctx, ln, h, 5*time.Second)
+	}()
+
+	body := make(chan string, 1)
+	go func() {
+		resp, e := http.Get("http://" + ln.Addr().String())
+		if e != nil {
+			body <- e.Error()
+			return
+		}
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		body <- string(data)
+	}()
+	<-h.started
+	cancel()
+	select {
+	case err = <-served:
+		t.Fatalf("server stopped before in-flight request finished: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(h.release)
+	assert.Equal(t, "done", <-body)
+	assert.NoError(t, <-served)
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Serve обслуживает запросы на ln, пока не отменён ctx; затем перестаёт принимать соединения и
+// ждёт завершения начатых запросов не дольше shutdownTimeout
+func Serve(ctx context.Context, ln net.Listener, h http.Handler, shutdownTimeout time.Duration) error {
+	const api = "Serve"
+
+	srv := &http.Server{
+		Handler:           h,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	served := make(chan error, 1)
+	go func() {
+		served <- srv.Serve(ln)
+	}()
+	select {
+	case err := <-served:
+		return errors.Wrap(err, api)
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		_ = srv.Close()
+		return errors.Wrap(err, api)
+	}
+	if err := <-served; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return errors.Wrap(err, api)
+	}
+	return nil
+}
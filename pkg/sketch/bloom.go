@@ -0,0 +1,96 @@
+package sketch
+
+import (
+	"math"
+)
+
+// BloomFilter ...
+type BloomFilter struct {
+	k    int
+	bits []uint64
+}
+
+// NewBloomFilter фильтр на capacity элементов с вероятностью ложного срабатывания fpRate
+func NewBloomFilter(capacity uint64, fpRate float64) *BloomFilter {
+	m := math.Ceil(-float64(capacity) * math.Log(fpRate) / (math.Ln2 * math.Ln2))
+	if m < 64 {
+		m = 64
+	}
+	k := int(math.Round(m / float64(capacity) * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+	return &BloomFilter{
+		k:    k,
+		bits: make([]uint64, (uint64(m)+63)/64),
+	}
+}
+
+// TestAndAdd добавляет элемент; возвращает true если он (вероятно) уже был добавлен
+func (f *BloomFilter) TestAndAdd(hash uint64) bool {
+	return f.probe(hash, true)
+}
+
+// Test возвращает true если элемент (вероятно) был добавлен
+func (f *BloomFilter) Test(hash uint64) bool {
+	return f.probe(hash, false)
+}
+
+// ScalableBloomFilter фильтр Блума, растущий вместе с числом элементов: заполненный фильтр дополняется
+// вдвое большим с вдвое меньшей вероятностью ложного срабатывания, так что суммарная вероятность не превышает fpRate
+type ScalableBloomFilter struct {
+	filters  []*BloomFilter
+	capacity uint64
+	fpRate   float64
+	count    uint64
+}
+
+// NewScalableBloomFilter фильтр с начальной ёмкостью capacity и вероятностью ложного срабатывания fpRate
+func NewScalableBloomFilter(capacity uint64, fpRate float64) *ScalableBloomFilter {
+	if capacity == 0 {
+		capacity = 1
+	}
+	f := &ScalableBloomFilter{capacity: capacity, fpRate: fpRate / 2}
+	f.filters = []*BloomFilter{NewBloomFilter(f.capacity, f.fpRate)}
+	return f
+}
+
+// TestAndAdd добавляет элемент; возвращает true если он (вероятно) уже был добавлен
+func (f *ScalableBloomFilter) TestAndAdd(hash uint64) bool {
+	last := len(f.filters) - 1
+	for _, prev := range f.filters[:last] {
+		if prev.Test(hash) {
+			return true
+		}
+	}
+	if f.filters[last].TestAndAdd(hash) {
+		return true
+	}
+	if f.count++; f.count >= f.capacity {
+		f.capacity *= 2
+		f.fpRate /= 2
+		f.count = 0
+		f.filters = append(f.filters, NewBloomFilter(f.capacity, f.fpRate))
+	}
+	return false
+}
+
+// ---------------------------------------- IMPL -------------------------------------
+
+func (f *BloomFilter) probe(hash uint64, add bool) bool {
+	h1, h2 := split(hash)
+	n := uint64(len(f.bits)) * 64
+	present := true
+	for i := 0; i < f.k; i++ {
+		j := (uint64(h1) + uint64(i)*uint64(h2)) % n
+		word, bit := j/64, uint64(1)<<(j%64)
+		if f.bits[word]&bit == 0 {
+			if !add {
+				return false
+			}
+			present = false
+			f.bits[word] |= bit
+		}
+	}
+	return present
+}
@@ -0,0 +1,63 @@
+package sketch
+
+import (
+	"math"
+)
+
+// CountMinSketch приближённые счётчики по ключам; оценка никогда не бывает меньше точного значения
+// и с вероятностью 1-delta превышает его не более чем на ErrorBound()
+type CountMinSketch struct {
+	eps      float64
+	total    uint64
+	counters [][]uint64
+}
+
+// NewCountMinSketch ...
+func NewCountMinSketch(eps, delta float64) *CountMinSketch {
+	width := int(math.Ceil(math.E / eps))
+	depth := int(math.Ceil(math.Log(1 / delta)))
+	if depth < 1 {
+		depth = 1
+	}
+	ret := &CountMinSketch{
+		eps:      eps,
+		counters: make([][]uint64, depth),
+	}
+	for i := range ret.counters {
+		ret.counters[i] = make([]uint64, width)
+	}
+	return ret
+}
+
+// Add увеличивает счётчик ключа и возвращает его новую оценку
+func (s *CountMinSketch) Add(hash uint64, n uint64) uint64 {
+	s.total += n
+	h1, h2 := split(hash)
+	est := uint64(math.MaxUint64)
+	for i, row := range s.counters {
+		j := (uint64(h1) + uint64(i)*uint64(h2)) % uint64(len(row))
+		row[j] += n
+		if row[j] < est {
+			est = row[j]
+		}
+	}
+	return est
+}
+
+// Estimate ...
+func (s *CountMinSketch) Estimate(hash uint64) uint64 {
+	h1, h2 := split(hash)
+	est := uint64(math.MaxUint64)
+	for i, row := range s.counters {
+		j := (uint64(h1) + uint64(i)*uint64(h2)) % uint64(len(row))
+		if row[j] < est {
+			est = row[j]
+		}
+	}
+	return est
+}
+
+// ErrorBound максимальное (с вероятностью 1-delta) завышение оценки
+func (s *CountMinSketch) ErrorBound() uint64 {
+	return uint64(math.Ceil(s.eps * float64(s.total)))
+}
@@ -0,0 +1,31 @@
+package sketch
+
+import (
+	"hash/fnv"
+)
+
+// Hash 64-битный хэш строки для скетчей
+func Hash(s string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s))
+	return mix(h.Sum64())
+}
+
+// mix финализатор murmur3: fnv плохо перемешивает старшие биты, а HLL берёт индекс регистра именно из них
+func mix(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+// split два независимых хэша для double hashing
+func split(h uint64) (uint32, uint32) {
+	h1, h2 := uint32(h), uint32(h>>32)
+	if h2 == 0 {
+		h2 = 1
+	}
+	return h1, h2
+}
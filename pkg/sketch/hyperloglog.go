@@ -0,0 +1,80 @@
+package sketch
+
+import (
+	"math"
+	"math/bits"
+)
+
+const (
+	hllMinPrecision = 4
+	hllMaxPrecision = 18
+)
+
+// HyperLogLog приближённый счётчик уникальных значений
+type HyperLogLog struct {
+	p         uint8
+	registers []uint8
+}
+
+// NewHyperLogLog HyperLogLog c относительной стандартной ошибкой не хуже errRate (в пределах допустимой точности)
+func NewHyperLogLog(errRate float64) *HyperLogLog {
+	p := uint8(hllMaxPrecision)
+	if errRate > 0 {
+		m := math.Pow(1.04/errRate, 2)
+		p = uint8(math.Ceil(math.Log2(m)))
+	}
+	if p < hllMinPrecision {
+		p = hllMinPrecision
+	} else if p > hllMaxPrecision {
+		p = hllMaxPrecision
+	}
+	return &HyperLogLog{
+		p:         p,
+		registers: make([]uint8, 1<<p),
+	}
+}
+
+// Add ...
+func (h *HyperLogLog) Add(hash uint64) {
+	idx := hash >> (64 - h.p)
+	w := hash<<h.p | 1<<(h.p-1)
+	if rho := uint8(bits.LeadingZeros64(w) + 1); rho > h.registers[idx] {
+		h.registers[idx] = rho
+	}
+}
+
+// Estimate оценка числа уникальных значений
+func (h *HyperLogLog) Estimate() uint64 {
+	m := float64(len(h.registers))
+	var sum float64
+	var zeros int
+	for _, r := range h.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+	e := h.alpha() * m * m / sum
+	if e <= 2.5*m && zeros > 0 {
+		e = m * math.Log(m/float64(zeros))
+	}
+	return uint64(e + 0.5)
+}
+
+// StdError относительная стандартная ошибка оценки
+func (h *HyperLogLog) StdError() float64 {
+	return 1.04 / math.Sqrt(float64(len(h.registers)))
+}
+
+func (h *HyperLogLog) alpha() float64 {
+	switch m := len(h.registers); m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/float64(m))
+	}
+}
@@ -0,0 +1,74 @@
+package sketch
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHyperLogLog(t *testing.T) {
+	for _, n := range []int{0, 10, 1000, 100000} {
+		h := NewHyperLogLog(0.01)
+		for i := 0; i < n; i++ {
+			h.Add(Hash(strconv.Itoa(i)))
+			h.Add(Hash(strconv.Itoa(i)))
+		}
+		est := float64(h.Estimate())
+		assert.InDelta(t, float64(n), est, 3*h.StdError()*float64(n)+1, "n=%d", n)
+	}
+}
+
+func TestHyperLogLogPrecision(t *testing.T) {
+	assert.Len(t, NewHyperLogLog(0.5).registers, 1<<hllMinPrecision)
+	assert.Len(t, NewHyperLogLog(0).registers, 1<<hllMaxPrecision)
+	h := NewHyperLogLog(0.02)
+	assert.LessOrEqual(t, h.StdError(), 0.02)
+}
+
+func TestCountMinSketch(t *testing.T) {
+	s := NewCountMinSketch(0.001, 0.01)
+	exact := make(map[string]uint64)
+	for i := 0; i < 50000; i++ {
+		k := strconv.Itoa(i % 997)
+		if i%10 == 0 {
+			k = "heavy"
+		}
+		exact[k]++
+		s.Add(Hash(k), 1)
+	}
+	for k, n := range exact {
+		est := s.Estimate(Hash(k))
+		assert.GreaterOrEqual(t, est, n, k)
+		assert.LessOrEqual(t, est, n+s.ErrorBound(), k)
+	}
+}
+
+func TestBloomFilter(t *testing.T) {
+	f := NewBloomFilter(10000, 0.01)
+	var fp int
+	for i := 0; i < 10000; i++ {
+		if f.TestAndAdd(Hash(strconv.Itoa(i))) {
+			fp++
+		}
+	}
+	assert.Less(t, fp, 300)
+	for i := 0; i < 10000; i++ {
+		assert.True(t, f.TestAndAdd(Hash(strconv.Itoa(i))))
+	}
+}
+
+func TestScalableBloomFilter(t *testing.T) {
+	f := NewScalableBloomFilter(100, 0.01)
+	var fp int
+	for i := 0; i < 100000; i++ {
+		if f.TestAndAdd(Hash(strconv.Itoa(i))) {
+			fp++
+		}
+	}
+	assert.Less(t, fp, 1000)
+	assert.Greater(t, len(f.filters), 5)
+	for i := 0; i < 100000; i += 7 {
+		assert.True(t, f.TestAndAdd(Hash(strconv.Itoa(i))))
+	}
+}
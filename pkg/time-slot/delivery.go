@@ -2,6 +2,7 @@ package time_slot
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -156,6 +157,17 @@ func (h Hour) MarshalJSON() ([]byte, error) {
 	return b.Bytes(), errors.Wrap(e, api)
 }
 
+// UnmarshalJSON ...
+func (h *Hour) UnmarshalJSON(data []byte) error {
+	const api = "Hour.UnmarshalJSON"
+
+	var s string
+	if e := json.Unmarshal(data, &s); e != nil {
+		return errors.Wrap(e, api)
+	}
+	return errors.Wrap(h.FromString([]byte(s)), api)
+}
+
 var (
 	s2wd = map[string]time.Weekday{
 		time.Sunday.String():    time.Sunday,
@@ -45,3 +45,16 @@ func TestHour(t *testing.T) {
 		assert.Equal(t, int(h), int(Hour(i)))
 	}
 }
+
+func TestHourMarshalUnmarshal(t *testing.T) {
+	for i := uint(0); i < 24; i++ {
+		b, e := json.Marshal(Hour(i))
+		assert.NoError(t, e)
+		var h Hour
+		assert.NoError(t, json.Unmarshal(b, &h))
+		assert.Equal(t, Hour(i), h)
+	}
+	var h Hour
+	assert.Error(t, json.Unmarshal([]byte(`"13PM"`), &h))
+	assert.Error(t, json.Unmarshal([]byte(`13`), &h))
+}
@@ -0,0 +1,131 @@
+package trend
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"sber-test/pkg/formatters"
+)
+
+// WriteTable таблица на каждый показатель раздела: строка на ряд, столбец на период и итоговый
+// рост от первого периода к последнему
+func WriteTable(w io.Writer, t Trend) error {
+	bw := bufio.NewWriter(w)
+	for i := 0; i < len(t.Series); {
+		// ряды одного показателя идут подряд
+		j := i + 1
+		for j < len(t.Series) && t.Series[j].Section == t.Series[i].Section && t.Series[j].Column == t.Series[i].Column {
+			j++
+		}
+		if i > 0 {
+			_, _ = fmt.Fprintln(bw)
+		}
+		if err := writeTable(bw, t.Periods, t.Series[i:j]); err != nil {
+			return err
+		}
+		i = j
+	}
+	return bw.Flush()
+}
+
+// WriteCSV ряды в длинном формате: строка на каждую точку
+func WriteCSV(w io.Writer, t Trend) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"section", "key", "column", "date", "source", "value", "delta", "growth", "label"})
+	for _, s := range t.Series {
+		for i, p := range s.Points {
+			_ = cw.Write([]string{
+				s.Section, formatters.EscapeCSVCell(strings.Join(s.Key, ", ")), s.Column,
+				t.Periods[i].Date.Format(DateLayout), formatters.EscapeCSVCell(t.Periods[i].Source),
+				value(p.Value), optional(p.Delta), optional(p.Growth), formatters.EscapeCSVCell(p.Label),
+			})
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ---------------------------------------- IMPL -------------------------------------
+
+func writeTable(w io.Writer, periods []Period, series []Series) error {
+	first := series[0]
+	_, _ = fmt.Fprintf(w, "%s: %s\n", first.Section, first.Column)
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	header := append([]string(nil), first.KeyColumns...)
+	for _, p := range periods {
+		header = append(header, p.Date.Format(DateLayout))
+	}
+	header = append(header, "growth")
+	_, _ = fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, s := range series {
+		row := append([]string(nil), s.Key...)
+		labels := variableLabels(s)
+		for _, p := range s.Points {
+			cell := value(p.Value)
+			if labels && p.Value != nil {
+				cell += " (" + p.Label + ")"
+			}
+			row = append(row, cell)
+		}
+		growth := optional(s.Growth)
+		if len(growth) > 0 {
+			growth += "%"
+		}
+		_, _ = fmt.Fprintln(tw, strings.Join(append(row, growth), "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	// tabwriter дополняет пробелами и пустой столбец growth
+	for _, line := range strings.SplitAfter(buf.String(), "\n") {
+		if len(line) > 0 {
+			_, _ = io.WriteString(w, strings.TrimRight(line, " \n")+"\n")
+		}
+	}
+	return nil
+}
+
+// variableLabels метки меняются от периода к периоду (например, busiest_postcode): их надо выводить
+func variableLabels(s Series) bool {
+	var label *string
+	for i, p := range s.Points {
+		switch {
+		case p.Value == nil:
+		case label == nil:
+			label = &s.Points[i].Label
+		case p.Label != *label:
+			return true
+		}
+	}
+	return false
+}
+
+func number(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// value пусто, если значения нет
+func value(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return number(*v)
+}
+
+func optional(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	s := number(*v)
+	if *v > 0 {
+		s = "+" + s
+	}
+	return s
+}
@@ -0,0 +1,190 @@
+// Package trend временные ряды показателей отчёта по серии периодов (например, ежедневных выгрузок)
+package trend
+
+import (
+	"encoding/json"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"sber-test/pkg/formatters"
+	"sber-test/pkg/processors" //nolint:goimports
+)
+
+// DateLayout формат дат периодов
+const DateLayout = "2006-01-02"
+
+type (
+	// Period период ряда: отчёт по одному файлу
+	Period struct {
+		Date   time.Time
+		Source string
+	}
+
+	// Point значение показателя в периоде; Value нет, если строки нет в отчёте периода, а раздел
+	// в нём неполный (--group-by с --limit)
+	Point struct {
+		Value *float64 `json:"value"`
+		// Delta и Growth (в процентах) изменение относительно предыдущего периода со значением; Growth нет,
+		// если предыдущее значение 0
+		Delta  *float64 `json:"delta,omitempty"`
+		Growth *float64 `json:"growth,omitempty"`
+		// Label нечисловые столбцы строки, например "postcode" в busiest_postcode
+		Label string `json:"label,omitempty"`
+	}
+
+	// Series ряд показателя Column строки Key раздела Section; Points по одной на период
+	Series struct {
+		Section string `json:"section"`
+		// KeyColumns имена ключевых столбцов раздела, Key - их значения
+		KeyColumns []string `json:"key_columns,omitempty"`
+		Key        []string `json:"key,omitempty"`
+		Column     string   `json:"column"`
+		Points     []Point  `json:"points"`
+		// Growth изменение от первого периода со значением к последнему в процентах
+		Growth *float64 `json:"growth,omitempty"`
+	}
+
+	// Trend ...
+	Trend struct {
+		Periods []Period `json:"periods"`
+		Series  []Series `json:"series"`
+	}
+)
+
+// MarshalJSON дата - в DateLayout
+func (p Period) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Date   string `json:"date"`
+		Source string `json:"source"`
+	}{p.Date.Format(DateLayout), p.Source})
+}
+
+// Build ряды по отчётам reports, построенным с одними и теми же параметрам по периодам periods
+// (в порядке дат): ряд на каждый числовой столбец каждой строки каждого раздела. Строки, которой
+// нет в отчёте периода, соответствует 0, а если раздел в нём неполный (Section.Truncated) - точка
+// без значения
+func Build(periods []Period, reports []processors.RecipeProcessorReport) Trend {
+	ret := Trend{Periods: periods, Series: []Series{}}
+	index := make(map[string]int)
+	// ряды одного показателя раздела должны идти подряд, а строки появляются в разных периодах
+	groups := make(map[string]int)
+	var groupOf []int
+	// truncated неполные разделы каждого периода
+	truncated := make([]map[string]bool, len(reports))
+	for p, report := range reports {
+		truncated[p] = make(map[string]bool)
+		for _, s := range formatters.Sections(report) {
+			truncated[p][s.Name] = s.Truncated
+			keys := s.KeyColumns()
+			for _, row := range s.Rows {
+				var label []string
+				for i := keys; i < len(s.Header); i++ {
+					if !formatters.IsMeasure(s.Header[i]) {
+						label = append(label, row[i])
+					}
+				}
+				for i := keys; i < len(s.Header); i++ {
+					if !formatters.IsMeasure(s.Header[i]) {
+						continue
+					}
+					id := strings.Join(append([]string{s.Name, s.Header[i]}, row[:keys]...), "\x00")
+					n, ok := index[id]
+					if !ok {
+						n = len(ret.Series)
+						index[id] = n
+						group := s.Name + "\x00" + s.Header[i]
+						if _, ok := groups[group]; !ok {
+							groups[group] = len(groups)
+						}
+						groupOf = append(groupOf, groups[group])
+						ret.Series = append(ret.Series, Series{
+							Section:    s.Name,
+							KeyColumns: append([]string(nil), s.Header[:keys]...),
+							Key:        append([]string(nil), row[:keys]...),
+							Column:     s.Header[i],
+							Points:     make([]Point, len(periods)),
+						})
+					}
+					v, _ := strconv.ParseFloat(row[i], 64)
+					ret.Series[n].Points[p] = Point{Value: &v, Label: strings.Join(label, ", ")}
+				}
+			}
+		}
+	}
+	for i := range ret.Series {
+		s := &ret.Series[i]
+		for p := range s.Points {
+			if s.Points[p].Value == nil && !truncated[p][s.Section] {
+				s.Points[p].Value = new(float64)
+			}
+		}
+		s.growth()
+	}
+	order := make([]int, len(ret.Series))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return groupOf[order[i]] < groupOf[order[j]]
+	})
+	sorted := make([]Series, 0, len(ret.Series))
+	for _, i := range order {
+		sorted = append(sorted, ret.Series[i])
+	}
+	ret.Series = sorted
+	return ret
+}
+
+var nameDateRE = regexp.MustCompile(`(\d{4})-?(\d{2})-?(\d{2})`)
+
+// DateFromName дата из имени файла: последнее вхождение YYYY-MM-DD или YYYYMMDD
+func DateFromName(name string) (time.Time, bool) {
+	all := nameDateRE.FindAllStringSubmatch(name, -1)
+	for i := len(all) - 1; i >= 0; i-- {
+		m := all[i]
+		if d, err := time.Parse(DateLayout, m[1]+"-"+m[2]+"-"+m[3]); err == nil {
+			return d, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// ---------------------------------------- IMPL -------------------------------------
+
+// growth точки без значения пропускаются
+func (s *Series) growth() {
+	var first, prev *float64
+	for i := range s.Points {
+		cur := s.Points[i].Value
+		if cur == nil {
+			continue
+		}
+		if prev != nil {
+			d := round(*cur-*prev, 1e6)
+			s.Points[i].Delta = &d
+			s.Points[i].Growth = percent(*prev, *cur)
+		} else {
+			first = cur
+		}
+		prev = cur
+	}
+	if first != nil && prev != first {
+		s.Growth = percent(*first, *prev)
+	}
+}
+
+func percent(from, to float64) *float64 {
+	if from == 0 {
+		return nil
+	}
+	v := round((to-from)/from*100, 100)
+	return &v
+}
+
+func round(v, scale float64) float64 {
+	return math.Round(v*scale) / scale
+}
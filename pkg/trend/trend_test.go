@@ -0,0 +1,160 @@
+package trend
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" //nolint:goimports
+	"sber-test/pkg/processors"
+	"sber-test/pkg/providers"
+	ts "sber-test/pkg/time-slot" //nolint:goimports
+)
+
+var monday = ts.ConstructDelivery(time.Monday, 10, 15)
+
+func report(t *testing.T, data providers.RecipeDeliveries) processors.RecipeProcessorReport {
+	ret, err := processors.NewRecipeReportProcessor(processors.ReportCounterPerRecipe(), processors.ReportBusiestPostcode()).
+		Process(context.Background(), data)
+	assert.NoError(t, err)
+	return ret
+}
+
+func testTrend(t *testing.T) Trend {
+	day := func(d int) Period {
+		return Period{Date: time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC), Source: "data-" + time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC).Format(DateLayout)}
+	}
+	return Build([]Period{day(1), day(2), day(3)}, []processors.RecipeProcessorReport{
+		report(t, providers.RecipeDeliveries{
+			{Recipe: "A", Postcode: "1", Delivery: monday},
+			{Recipe: "A", Postcode: "1", Delivery: ts.ConstructDelivery(time.Friday, 10, 15)},
+		}),
+		report(t, providers.RecipeDeliveries{
+			{Recipe: "A", Postcode: "1", Delivery: monday},
+			{Recipe: "B", Postcode: "1", Delivery: ts.ConstructDelivery(time.Friday, 10, 15)},
+		}),
+		report(t, providers.RecipeDeliveries{
+			{Recipe: "A", Postcode: "2", Delivery: monday},
+			{Recipe: "A", Postcode: "2", Delivery: ts.ConstructDelivery(time.Friday, 10, 15)},
+			{Recipe: "A", Postcode: "2", Delivery: ts.ConstructDelivery(time.Sunday, 10, 15)},
+			{Recipe: "B", Postcode: "2", Delivery: monday},
+		}),
+	})
+}
+
+func TestBuild(t *testing.T) {
+	tr := testTrend(t)
+	assert.Len(t, tr.Series, 3)
+	a, b, busiest := tr.Series[0], tr.Series[1], tr.Series[2]
+
+	assert.Equal(t, []string{"A"}, a.Key)
+	assert.Equal(t, []string{"recipe"}, a.KeyColumns)
+	assert.Equal(t, []string{"2", "1", "3"}, values(a))
+	assert.Equal(t, -50.0, *a.Points[1].Growth)
+	assert.Equal(t, 200.0, *a.Points[2].Growth)
+	assert.Equal(t, 50.0, *a.Growth)
+
+	// рецепта B нет в первом периоде: 0 и без роста в процентах
+	assert.Equal(t, "count_per_recipe", b.Section)
+	assert.Equal(t, []string{"0", "1", "1"}, values(b))
+	assert.Nil(t, b.Points[1].Growth)
+	assert.Equal(t, 1.0, *b.Points[1].Delta)
+	assert.Nil(t, b.Growth)
+
+	assert.Equal(t, "busiest_postcode", busiest.Section)
+	assert.Equal(t, "delivery_count", busiest.Column)
+	assert.Nil(t, busiest.Key)
+	assert.Equal(t, []string{"1", "1", "2"}, []string{busiest.Points[0].Label, busiest.Points[1].Label, busiest.Points[2].Label})
+
+	data, err := json.Marshal(tr.Periods[0])
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"date": "2026-10-01", "source": "data-2026-10-01"}`, string(data))
+}
+
+func TestBuildTruncated(t *testing.T) {
+	build := func(data providers.RecipeDeliveries) processors.RecipeProcessorReport {
+		top, err := processors.ReportGroupBy(processors.GroupBySpec{
+			By:      []processors.Dimension{processors.DimPostcode},
+			OrderBy: []processors.GroupOrder{{Column: processors.OrderColumnCount, Desc: true}},
+			Limit:   1,
+		})
+		assert.NoError(t, err)
+		ret, err := processors.NewRecipeReportProcessor(top).Process(context.Background(), data)
+		assert.NoError(t, err)
+		return ret
+	}
+	tr := Build(make([]Period, 3), []processors.RecipeProcessorReport{
+		build(providers.RecipeDeliveries{{Postcode: "1"}, {Postcode: "1"}, {Postcode: "2"}}),
+		build(providers.RecipeDeliveries{{Postcode: "1"}, {Postcode: "2"}, {Postcode: "2"}}),
+		build(providers.RecipeDeliveries{{Postcode: "1"}}),
+	})
+	assert.Len(t, tr.Series, 2)
+	// postcode 2 не попал в --limit в первом периоде - значение неизвестно; в третьем периоде
+	// раздел полный - 0
+	assert.Equal(t, []string{"2", "", "1"}, values(tr.Series[0]))
+	assert.Equal(t, []string{"", "2", "0"}, values(tr.Series[1]))
+	assert.Nil(t, tr.Series[1].Points[1].Delta)
+	assert.Equal(t, -2.0, *tr.Series[1].Points[2].Delta)
+
+	data, err := json.Marshal(tr.Series[1].Points[0])
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"value": null}`, string(data))
+}
+
+func values(s Series) []string {
+	var ret []string
+	for _, p := range s.Points {
+		ret = append(ret, value(p.Value))
+	}
+	return ret
+}
+
+func TestWriteTable(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, WriteTable(&buf, testTrend(t)))
+	assert.Equal(t, `count_per_recipe: count
+recipe  2026-10-01  2026-10-02  2026-10-03  growth
+A       2           1           3           +50%
+B       0           1           1
+
+busiest_postcode: delivery_count
+2026-10-01  2026-10-02  2026-10-03  growth
+2 (1)       2 (1)       3 (2)       +50%
+`, buf.String())
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, WriteCSV(&buf, testTrend(t)))
+	assert.Equal(t, `section,key,column,date,source,value,delta,growth,label
+count_per_recipe,A,count,2026-10-01,data-2026-10-01,2,,,
+count_per_recipe,A,count,2026-10-02,data-2026-10-02,1,-1,-50,
+count_per_recipe,A,count,2026-10-03,data-2026-10-03,3,+2,+200,
+count_per_recipe,B,count,2026-10-01,data-2026-10-01,0,,,
+count_per_recipe,B,count,2026-10-02,data-2026-10-02,1,+1,,
+count_per_recipe,B,count,2026-10-03,data-2026-10-03,1,0,0,
+busiest_postcode,,delivery_count,2026-10-01,data-2026-10-01,2,,,1
+busiest_postcode,,delivery_count,2026-10-02,data-2026-10-02,2,0,0,1
+busiest_postcode,,delivery_count,2026-10-03,data-2026-10-03,3,+1,+50,2
+`, buf.String())
+}
+
+func TestDateFromName(t *testing.T) {
+	for name, want := range map[string]string{
+		"export-2026-10-01.json":          "2026-10-01",
+		"20261002.json":                   "2026-10-02",
+		"v2-2025-01-01-fix-20261003.json": "2026-10-03",
+		"export-2026-13-01.json":          "",
+		"export.json":                     "",
+	} {
+		d, ok := DateFromName(name)
+		if len(want) == 0 {
+			assert.False(t, ok, name)
+			continue
+		}
+		assert.True(t, ok, name)
+		assert.Equal(t, want, d.Format(DateLayout), name)
+	}
+}
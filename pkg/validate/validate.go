@@ -0,0 +1,356 @@
+// Package validate проверка файла доставок без построения отчёта: каждая запись проверяется
+// отдельно, ошибка в одной записи не останавливает проверку остальных
+package validate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"      //nolint:goimports
+	ts "sber-test/pkg/time-slot" //nolint:goimports
+)
+
+// Severity ...
+type Severity string
+
+// severities
+const (
+	// SeverityError запись не будет прочитана или будет посчитана неверно
+	SeverityError Severity = "error"
+	// SeverityWarning запись будет прочитана, но, вероятно, содержит ошибку в данных
+	SeverityWarning Severity = "warning"
+)
+
+// Code вид проблемы
+type Code string
+
+// codes
+const (
+	CodeSyntax         Code = "syntax"          // файл или запись - не JSON; проверка файла прекращается
+	CodeWrongType      Code = "wrong_type"      // запись - не объект или поле не того типа
+	CodeMissingField   Code = "missing_field"   // нет "postcode", "recipe" или "delivery"
+	CodeEmptyValue     Code = "empty_value"     // пустой "postcode" или "recipe"
+	CodeUnknownField   Code = "unknown_field"   // поле, которого нет в models.RecipeDelivery
+	CodeDeliveryFormat Code = "delivery_format" // "delivery" не в виде "Weekday 10AM - 3PM"
+	CodeUnknownWeekday Code = "unknown_weekday"
+	CodeBadHour        Code = "bad_hour"
+	CodeWindowOrder    Code = "window_order"  // начало окна доставки не раньше конца
+	CodeOutOfPolicy    Code = "out_of_policy" // окно доставки выходит за Options.Hours
+	CodeDuplicate      Code = "duplicate"     // запись совпадает с одной из предыдущих
+	CodeTrailingData   Code = "trailing_data" // данные после массива записей
+	CodeRead           Code = "read"          // файл не удалось открыть или прочитать
+)
+
+// RecordFile Issue.Record проблемы файла целиком
+const RecordFile = -1
+
+type (
+	// Issue проблема записи Record (индекс в массиве, с 0; -1 - проблема файла целиком)
+	Issue struct {
+		Record   int      `json:"record"`
+		Severity Severity `json:"severity"`
+		Code     Code     `json:"code"`
+		Field    string   `json:"field,omitempty"`
+		// Value значение поля (укороченное)
+		Value   string `json:"value,omitempty"`
+		Message string `json:"message"`
+	}
+
+	// Hours допустимые часы доставки: окно должно начинаться не раньше From и заканчиваться не позже To
+	Hours struct {
+		From, To ts.Hour
+	}
+
+	// Options ...
+	Options struct {
+		// Hours допустимые часы доставки; nil - не проверять
+		Hours *Hours
+	}
+
+	// Summary итоги проверки
+	Summary struct {
+		Records  int `json:"records"`
+		Errors   int `json:"errors"`
+		Warnings int `json:"warnings"`
+	}
+)
+
+// ParseHours "6AM-10PM"
+func ParseHours(s string) (Hours, error) {
+	var ret Hours
+	from, to, ok := strings.Cut(s, "-")
+	if !ok {
+		return ret, errors.Errorf("hours '%s' are not 'from-to'", s)
+	}
+	err := ret.From.FromString([]byte(strings.TrimSpace(from)))
+	if err == nil {
+		err = ret.To.FromString([]byte(strings.TrimSpace(to)))
+	}
+	if err != nil {
+		return ret, errors.Wrapf(err, "hours '%s'", s)
+	}
+	if ret.From >= ret.To {
+		return ret, errors.Errorf("hours '%s': from is not before to", s)
+	}
+	return ret, nil
+}
+
+// Severity наибольшая серьёзность найденных проблем; пусто - проблем нет
+func (s Summary) Severity() Severity {
+	switch {
+	case s.Errors > 0:
+		return SeverityError
+	case s.Warnings > 0:
+		return SeverityWarning
+	}
+	return ""
+}
+
+// Validate проверяет массив записей JSON из r и передаёт найденные проблемы в report по мере чтения;
+// ошибка возвращается, только если r не удалось прочитать или её вернул report
+func Validate(ctx context.Context, r io.Reader, opts Options, report func(Issue) error) (Summary, error) {
+	const api = "validate.Validate"
+
+	v := validator{opts: opts, report: report, seen: make(map[string]int)}
+	err := v.run(ctx, r)
+	if err == errStop {
+		err = nil
+	}
+	return v.summary, errors.Wrap(err, api)
+}
+
+// ---------------------------------------- IMPL -------------------------------------
+
+// maxValueLen длина Issue.Value
+const maxValueLen = 64
+
+var knownFields = map[string]bool{"postcode": true, "recipe": true, "delivery": true}
+
+// errStop файл дальше не читается после проблемы CodeSyntax
+var errStop = errors.New("stop")
+
+// deliveryPartsRE части "delivery" для диагностики записей, которые не удалось прочитать
+var deliveryPartsRE = regexp.MustCompile(`^\s*(\S+)\s+(\S+)\s*-\s*(\S+)\s*$`)
+
+type validator struct {
+	opts    Options
+	report  func(Issue) error
+	summary Summary
+	// seen первая запись с данными "postcode", "recipe" и "delivery"
+	seen map[string]int
+}
+
+// readError ошибка чтения r, а не синтаксиса JSON
+type readError struct {
+	error
+}
+
+type errReader struct {
+	r io.Reader
+}
+
+func (r errReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if err != nil && err != io.EOF {
+		err = readError{err}
+	}
+	return n, err
+}
+
+func (v *validator) run(ctx context.Context, r io.Reader) error {
+	dec := json.NewDecoder(errReader{r})
+	if err := v.syntax(RecordFile, dec.Token, json.Delim('['), "file is not a JSON array of records"); err != nil {
+		return err
+	}
+	for n := 0; dec.More(); n++ {
+		if n%1024 == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return v.stop(n, err)
+		}
+		v.summary.Records++
+		if err := v.record(n, raw); err != nil {
+			return err
+		}
+	}
+	if err := v.syntax(v.summary.Records, dec.Token, json.Delim(']'), "array of records is not closed"); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		var re readError
+		if errors.As(err, &re) {
+			return re.error
+		}
+		return v.add(Issue{Record: RecordFile, Severity: SeverityWarning, Code: CodeTrailingData,
+			Message: "data after array of records is ignored"})
+	}
+	return nil
+}
+
+// syntax читает токен want; иначе - проблема CodeSyntax
+func (v *validator) syntax(n int, token func() (json.Token, error), want json.Delim, message string) error {
+	t, err := token()
+	if err == nil && t == want {
+		return nil
+	}
+	if err == nil || err == io.EOF {
+		err = errors.New(message)
+	}
+	return v.stop(n, err)
+}
+
+// stop проблема CodeSyntax, после которой файл дальше не читается: возвращает errStop
+func (v *validator) stop(n int, err error) error {
+	var re readError
+	if errors.As(err, &re) {
+		return re.error
+	}
+	if err == io.ErrUnexpectedEOF {
+		err = errors.New("unexpected end of file")
+	}
+	if err = v.add(Issue{Record: n, Severity: SeverityError, Code: CodeSyntax, Message: err.Error()}); err != nil {
+		return err
+	}
+	return errStop
+}
+
+func (v *validator) add(issue Issue) error {
+	if issue.Severity == SeverityError {
+		v.summary.Errors++
+	} else {
+		v.summary.Warnings++
+	}
+	return v.report(issue)
+}
+
+func (v *validator) record(n int, raw json.RawMessage) error {
+	var issues []Issue
+	problem := func(sev Severity, code Code, field string, value json.RawMessage, format string, args ...interface{}) {
+		issues = append(issues, Issue{
+			Record: n, Severity: sev, Code: code, Field: field, Value: shorten(value), Message: fmt.Sprintf(format, args...),
+		})
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil || fields == nil {
+		problem(SeverityError, CodeWrongType, "", raw, "record is not an object")
+		return v.addAll(issues)
+	}
+	values := make(map[string]string)
+	for _, name := range []string{"postcode", "recipe", "delivery"} {
+		value, ok := fields[name]
+		if !ok {
+			problem(SeverityError, CodeMissingField, name, nil, "record has no '%s' field", name)
+			continue
+		}
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			problem(SeverityError, CodeWrongType, name, value, "'%s' is not a string", name)
+			continue
+		}
+		values[name] = s
+		if name != "delivery" && len(strings.TrimSpace(s)) == 0 {
+			problem(SeverityError, CodeEmptyValue, name, value, "'%s' is empty", name)
+		}
+	}
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !knownFields[name] {
+			problem(SeverityWarning, CodeUnknownField, name, fields[name], "unknown field '%s' is ignored", name)
+		}
+	}
+	if s, ok := values["delivery"]; ok {
+		issues = append(issues, v.delivery(n, fields["delivery"], s)...)
+	}
+	if len(values) == 3 {
+		key := values["postcode"] + "\x00" + values["recipe"] + "\x00" + values["delivery"]
+		if first, ok := v.seen[key]; ok {
+			problem(SeverityWarning, CodeDuplicate, "", nil, "record duplicates record %d", first)
+		} else {
+			v.seen[key] = n
+		}
+	}
+	return v.addAll(issues)
+}
+
+// delivery проверки "delivery": формат, как его читает ts.Delivery, и окно доставки
+func (v *validator) delivery(n int, raw json.RawMessage, s string) []Issue {
+	issue := func(sev Severity, code Code, format string, args ...interface{}) []Issue {
+		return []Issue{{
+			Record: n, Severity: sev, Code: code, Field: "delivery", Value: shorten(raw), Message: fmt.Sprintf(format, args...),
+		}}
+	}
+	var d ts.Delivery
+	if err := d.UnmarshalJSON(raw); err != nil {
+		parts := deliveryPartsRE.FindStringSubmatch(s)
+		if parts == nil {
+			return issue(SeverityError, CodeDeliveryFormat, "'delivery' is not like 'Monday 10AM - 3PM'")
+		}
+		if !isWeekday(parts[1]) {
+			return issue(SeverityError, CodeUnknownWeekday, "unknown weekday '%s'; use English names like 'Monday'", parts[1])
+		}
+		for _, h := range parts[2:] {
+			var hour ts.Hour
+			if e := hour.FromString([]byte(h)); e != nil || !strings.HasSuffix(strings.ToUpper(h), "M") {
+				return issue(SeverityError, CodeBadHour, "bad hour '%s'; use 12-hour time like '10AM'", h)
+			}
+		}
+		return issue(SeverityError, CodeDeliveryFormat, "'delivery' is not like 'Monday 10AM - 3PM'")
+	}
+	if d.From >= d.To {
+		return issue(SeverityError, CodeWindowOrder, "delivery window starts at %s, not before it ends at %s", d.From, d.To)
+	}
+	if h := v.opts.Hours; h != nil && (d.From < h.From || d.To > h.To) {
+		return issue(SeverityWarning, CodeOutOfPolicy, "delivery window %s - %s is out of delivery hours %s - %s",
+			d.From, d.To, h.From, h.To)
+	}
+	return nil
+}
+
+func (v *validator) addAll(issues []Issue) error {
+	for _, issue := range issues {
+		if err := v.add(issue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isWeekday(s string) bool {
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		if wd.String() == s {
+			return true
+		}
+	}
+	return false
+}
+
+func shorten(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) != nil {
+		var buf bytes.Buffer
+		if json.Compact(&buf, raw) == nil {
+			s = buf.String()
+		} else {
+			s = string(raw)
+		}
+	}
+	if r := []rune(s); len(r) > maxValueLen {
+		s = string(r[:maxValueLen]) + "..."
+	}
+	return s
+}
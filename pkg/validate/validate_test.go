@@ -0,0 +1,116 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert" //nolint:goimports
+)
+
+func validate(t *testing.T, data string, opts Options) (Summary, []Issue) {
+	var issues []Issue
+	summary, err := Validate(context.Background(), strings.NewReader(data), opts, func(issue Issue) error {
+		issues = append(issues, issue)
+		return nil
+	})
+	assert.NoError(t, err)
+	return summary, issues
+}
+
+// codes "record:code:field" проблем
+func codes(issues []Issue) []string {
+	var ret []string
+	for _, i := range issues {
+		ret = append(ret, fmt.Sprintf("%d:%s:%s", i.Record, i.Code, i.Field))
+	}
+	return ret
+}
+
+func TestValidate(t *testing.T) {
+	hours, err := ParseHours("6AM-10PM")
+	assert.NoError(t, err)
+	summary, issues := validate(t, `[
+		{"postcode": "10224", "recipe": "Creamy Dill Chicken", "delivery": "Wednesday 1AM - 7PM"},
+		{"postcode": "10208", "recipe": "Speedy Steak Fajitas", "delivery": "Thursday 7AM - 5PM"},
+		{"postcode": "10208", "recipe": "Speedy Steak Fajitas", "delivery": "Thursday 7AM - 5PM"},
+		{"postcode": "", "recipe": "Tex-Mex Tilapia", "delivery": "Funday 7AM - 5PM"},
+		{"postcode": 10120, "delivery": "Monday 5PM - 9AM", "comment": "call first"},
+		{"postcode": "10120", "recipe": "Tex-Mex Tilapia", "delivery": "Monday 10 - 15"},
+		{"postcode": "10120", "recipe": "Tex-Mex Tilapia", "delivery": "Monday 13PM - 3PM"},
+		{"postcode": "10120", "recipe": "Tex-Mex Tilapia", "delivery": "tomorrow"},
+		{"postcode": "10120", "recipe": "Tex-Mex Tilapia", "delivery": "Monday 9AM - 3PM", "region": "north"},
+		"10120",
+		{"postcode": "10121", "recipe": "Tex-Mex Tilapia", "delivery": "Monday 9AM - 3PM", "region": {"zone": "north"}, "catalog": {"category": "fish"}}
+	]`, Options{Hours: &hours})
+	assert.Equal(t, []string{
+		"0:out_of_policy:delivery",
+		"2:duplicate:",
+		"3:empty_value:postcode",
+		"3:unknown_weekday:delivery",
+		"4:wrong_type:postcode",
+		"4:missing_field:recipe",
+		"4:unknown_field:comment",
+		"4:window_order:delivery",
+		"5:bad_hour:delivery",
+		"6:bad_hour:delivery",
+		"7:delivery_format:delivery",
+		"8:unknown_field:region",
+		"9:wrong_type:",
+		"10:unknown_field:catalog",
+		"10:unknown_field:region",
+	}, codes(issues))
+	assert.Equal(t, Summary{Records: 11, Errors: 9, Warnings: 6}, summary)
+	assert.Equal(t, SeverityError, summary.Severity())
+	assert.Equal(t, "record duplicates record 1", issues[1].Message)
+	assert.Equal(t, "10120", issues[4].Value)
+	assert.Equal(t, "Funday 7AM - 5PM", issues[3].Value)
+	assert.Equal(t, "delivery window 1AM - 7PM is out of delivery hours 6AM - 10PM", issues[0].Message)
+
+	// без Options.Hours окно не проверяется
+	summary, issues = validate(t, `[{"postcode": "1", "recipe": "A", "delivery": "Wednesday 1AM - 7PM"}]`, Options{})
+	assert.Empty(t, issues)
+	assert.Equal(t, Summary{Records: 1}, summary)
+	assert.Equal(t, Severity(""), summary.Severity())
+}
+
+func TestValidateSyntax(t *testing.T) {
+	for _, c := range []struct {
+		data  string
+		codes []string
+	}{
+		{``, []string{"-1:syntax:"}},
+		{`{"postcode": "1"}`, []string{"-1:syntax:"}},
+		{`[{"postcode": "1", "recipe": "A", "delivery": "Monday 9AM - 3PM"}, {"postcode": `, []string{"1:syntax:"}},
+		{`[{"postcode": "1", "recipe": "A", "delivery": "Monday 9AM - 3PM"} {}]`, []string{"1:syntax:"}},
+		{`[{"postcode": "1", "recipe": "A", "delivery": "Monday 9AM - 3PM"}`, []string{"1:syntax:"}},
+		{`[] []`, []string{"-1:trailing_data:"}},
+		{"[]\n", nil},
+	} {
+		_, issues := validate(t, c.data, Options{})
+		assert.Equal(t, c.codes, codes(issues), c.data)
+	}
+}
+
+func TestValidateReadError(t *testing.T) {
+	_, err := Validate(context.Background(), iotest.TimeoutReader(strings.NewReader(`[{"postcode": "1"}, `)), Options{},
+		func(Issue) error { return nil })
+	assert.ErrorIs(t, err, iotest.ErrTimeout)
+
+	stop := io.ErrShortWrite
+	_, err = Validate(context.Background(), strings.NewReader(`[1, 2]`), Options{}, func(Issue) error { return stop })
+	assert.ErrorIs(t, err, stop)
+}
+
+func TestParseHours(t *testing.T) {
+	h, err := ParseHours("6AM - 10PM")
+	assert.NoError(t, err)
+	assert.Equal(t, Hours{From: 6, To: 22}, h)
+	for _, s := range []string{"10PM-6AM", "6AM", "6AM-25"} {
+		_, err = ParseHours(s)
+		assert.Error(t, err, s)
+	}
+}
@@ -0,0 +1,71 @@
+//go:build linux
+
+package watch
+
+import (
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// inotifyMask события каталога: файл дописан и закрыт, заменён переименованием, удалён;
+// наблюдение за каталогом, а не за файлом, переживает замену файла редактором
+const inotifyMask = syscall.IN_CLOSE_WRITE | syscall.IN_MOVED_TO | syscall.IN_MOVED_FROM |
+	syscall.IN_DELETE | syscall.IN_ONLYDIR
+
+func inotify(ctx context.Context, paths []string) (<-chan struct{}, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, os.NewSyscallError("inotify_init1", err)
+	}
+	// неблокирующий дескриптор обслуживается poller'ом Go: Close прерывает Read
+	f := os.NewFile(uintptr(fd), "inotify")
+	names := make(map[int32]map[string]bool)
+	for _, p := range paths {
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+		wd, err := syscall.InotifyAddWatch(fd, filepath.Dir(abs), inotifyMask)
+		if err != nil {
+			_ = f.Close()
+			return nil, os.NewSyscallError("inotify_add_watch", err)
+		}
+		if names[int32(wd)] == nil {
+			names[int32(wd)] = make(map[string]bool)
+		}
+		names[int32(wd)][filepath.Base(abs)] = true
+	}
+	ch := make(chan struct{}, 1)
+	go func() {
+		<-ctx.Done()
+		_ = f.Close()
+	}()
+	go func() {
+		defer close(ch)
+		buf := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+		for {
+			n, err := f.Read(buf)
+			if err != nil {
+				return
+			}
+			for off := 0; off+syscall.SizeofInotifyEvent <= n; {
+				// struct inotify_event { int wd; uint32 mask; uint32 cookie; uint32 len; char name[]; }
+				wd := int32(binary.NativeEndian.Uint32(buf[off:]))
+				mask := binary.NativeEndian.Uint32(buf[off+4:])
+				nameLen := int(binary.NativeEndian.Uint32(buf[off+12:]))
+				off += syscall.SizeofInotifyEvent
+				name := strings.TrimRight(string(buf[off:off+nameLen]), "\x00")
+				off += nameLen
+				if mask&syscall.IN_Q_OVERFLOW != 0 || names[wd][name] {
+					notify(ch)
+				}
+			}
+		}
+	}()
+	return ch, nil
+}
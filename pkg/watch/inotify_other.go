@@ -0,0 +1,13 @@
+//go:build !linux
+
+package watch
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+func inotify(context.Context, []string) (<-chan struct{}, error) {
+	return nil, errors.New("inotify is not supported")
+}
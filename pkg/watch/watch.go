@@ -0,0 +1,151 @@
+// Package watch уведомления об изменении файлов: inotify на Linux, опрос размера и времени
+// изменения на остальных системах и там, где inotify недоступен
+package watch
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Способы наблюдения
+const (
+	MethodInotify = "inotify"
+	MethodPoll    = "poll"
+)
+
+// Options ...
+type Options struct {
+	// Poll опрашивать файлы, даже если доступен inotify
+	Poll bool
+	// Interval период опроса; по умолчанию секунда
+	Interval time.Duration
+	// Delay серия изменений с паузами короче Delay считается одним изменением; по умолчанию 100ms
+	Delay time.Duration
+}
+
+// Watcher ...
+type Watcher struct {
+	// C после каждого изменения (записи, замены, удаления) одного из файлов приходит значение;
+	// изменения, случившиеся пока значение не прочитано, объединяются. Закрывается после отмены ctx
+	C <-chan struct{}
+	// Method MethodInotify или MethodPoll
+	Method string
+}
+
+// Watch наблюдает за файлами paths, пока не отменён ctx; файлы могут и не существовать
+func Watch(ctx context.Context, paths []string, opts Options) (*Watcher, error) {
+	const api = "watch.Watch"
+
+	if len(paths) == 0 {
+		return nil, errors.Errorf("%s: no files to watch", api)
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = time.Second
+	}
+	if opts.Delay <= 0 {
+		opts.Delay = 100 * time.Millisecond
+	}
+	ret := &Watcher{Method: MethodInotify}
+	var raw <-chan struct{}
+	var err error
+	if !opts.Poll {
+		raw, err = inotify(ctx, paths)
+	}
+	if opts.Poll || err != nil {
+		ret.Method = MethodPoll
+		raw = poll(ctx, paths, opts.Interval)
+	}
+	ret.C = debounce(ctx, raw, opts.Delay)
+	return ret, nil
+}
+
+// ---------------------------------------- IMPL -------------------------------------
+
+// notify неблокирующая отправка: если значение уже ждёт в буфере, новое с ним объединяется
+func notify(ch chan<- struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+func debounce(ctx context.Context, raw <-chan struct{}, delay time.Duration) <-chan struct{} {
+	out := make(chan struct{}, 1)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-raw:
+				if !ok {
+					return
+				}
+			}
+			timer := time.NewTimer(delay)
+		quiet:
+			for {
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return
+				case _, ok := <-raw:
+					if !ok {
+						timer.Stop()
+						return
+					}
+					timer.Reset(delay)
+				case <-timer.C:
+					break quiet
+				}
+			}
+			notify(out)
+		}
+	}()
+	return out
+}
+
+type fileState struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+func stat(paths []string) []fileState {
+	ret := make([]fileState, len(paths))
+	for i, p := range paths {
+		if info, err := os.Stat(p); err == nil {
+			ret[i] = fileState{exists: true, size: info.Size(), modTime: info.ModTime()}
+		}
+	}
+	return ret
+}
+
+func poll(ctx context.Context, paths []string, interval time.Duration) <-chan struct{} {
+	ch := make(chan struct{}, 1)
+	prev := stat(paths)
+	go func() {
+		defer close(ch)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			cur := stat(paths)
+			for i := range cur {
+				if cur[i] != prev[i] {
+					notify(ch)
+					break
+				}
+			}
+			prev = cur
+		}
+	}()
+	return ch
+}
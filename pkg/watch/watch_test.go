@@ -0,0 +1,72 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func waitChange(t *testing.T, w *Watcher, want bool) {
+	t.Helper()
+	timeout := 2 * time.Second
+	if !want {
+		timeout = 200 * time.Millisecond
+	}
+	select {
+	case _, ok := <-w.C:
+		assert.True(t, ok, "channel is closed")
+		assert.True(t, want, "unexpected change")
+	case <-time.After(timeout):
+		assert.False(t, want, "change is not reported")
+	}
+}
+
+func TestWatch(t *testing.T) {
+	for _, poll := range []bool{false, true} {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "data.json")
+		assert.NoError(t, os.WriteFile(path, []byte(`[]`), 0o600))
+		ctx, cancel := context.WithCancel(context.Background())
+		w, err := Watch(ctx, []string{path}, Options{Poll: poll, Interval: 10 * time.Millisecond, Delay: 20 * time.Millisecond})
+		assert.NoError(t, err)
+		if !poll && runtime.GOOS == "linux" {
+			assert.Equal(t, MethodInotify, w.Method)
+		} else {
+			assert.Equal(t, MethodPoll, w.Method)
+		}
+
+		// другие файлы каталога не интересны
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "other.json"), []byte(`[{}]`), 0o600))
+		waitChange(t, w, false)
+
+		// серия записей - одно изменение
+		for i := 1; i <= 3; i++ {
+			assert.NoError(t, os.WriteFile(path, make([]byte, i), 0o600))
+		}
+		waitChange(t, w, true)
+		waitChange(t, w, false)
+
+		// замена файла переименованием, как делают редакторы
+		tmp := filepath.Join(dir, ".data.json.swp")
+		assert.NoError(t, os.WriteFile(tmp, []byte(`[{}, {}]`), 0o600))
+		assert.NoError(t, os.Rename(tmp, path))
+		waitChange(t, w, true)
+
+		assert.NoError(t, os.Remove(path))
+		waitChange(t, w, true)
+
+		cancel()
+		for range w.C {
+		}
+	}
+}
+
+func TestWatchNoFiles(t *testing.T) {
+	_, err := Watch(context.Background(), nil, Options{})
+	assert.Error(t, err)
+}